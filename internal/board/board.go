@@ -0,0 +1,93 @@
+// Package board turns a loaded gh.Project into columns: grouping by a
+// SingleSelect field, filtering and sorting. It is shared by the TUI and the
+// non-interactive commands so they agree on what a board looks like.
+package board
+
+import (
+	"fmt"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// NoValueOptionID is the pseudo option id of the trailing column that
+// collects items without a value for the grouping field (or whose option was
+// deleted from the project).
+const NoValueOptionID = ""
+
+type Column struct {
+	OptionID string
+	Name     string
+	Color    string // the option's GitHub colour, "" for the "No …" column
+	// Description is the option's description from the project settings.
+	Description string
+	Items       []gh.Item
+}
+
+// Group splits the project's items into one column per option of the named
+// SingleSelect field, in the field's option order. An empty field name groups
+// by Status. Items without a matching option land in a trailing "No <field>"
+// column, which is only present when non-empty.
+func Group(p *gh.Project, field string) ([]Column, error) {
+	if p == nil {
+		return nil, nil
+	}
+	f, err := GroupField(p, field)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]Column, 0, len(f.Options)+1)
+	index := make(map[string]int, len(f.Options))
+	for _, opt := range f.Options {
+		index[opt.ID] = len(cols)
+		cols = append(cols, Column{OptionID: opt.ID, Name: opt.Name, Color: opt.Color, Description: opt.Description})
+	}
+
+	var orphans []gh.Item
+	for _, item := range p.Items {
+		optionID := OptionID(p, f, item)
+		if i, ok := index[optionID]; ok && optionID != NoValueOptionID {
+			cols[i].Items = append(cols[i].Items, item)
+			continue
+		}
+		orphans = append(orphans, item)
+	}
+	if len(orphans) > 0 {
+		cols = append(cols, Column{OptionID: NoValueOptionID, Name: "No " + f.Name, Items: orphans})
+	}
+	return cols, nil
+}
+
+// GroupField resolves the field to group by; "" means Status.
+func GroupField(p *gh.Project, field string) (gh.SingleSelectField, error) {
+	if field == "" {
+		if p.Status.ID == "" {
+			return gh.SingleSelectField{}, fmt.Errorf("project has no Status field")
+		}
+		return p.Status, nil
+	}
+	f, ok := p.Field(field)
+	if !ok {
+		return gh.SingleSelectField{}, fmt.Errorf("project has no SingleSelect field %q", field)
+	}
+	return f, nil
+}
+
+// OptionID returns the option id the item holds for field f. Status uses the
+// dedicated StatusOptionID; other fields are resolved from the option name
+// stored in item.Fields.
+func OptionID(p *gh.Project, f gh.SingleSelectField, item gh.Item) string {
+	if f.ID == p.Status.ID {
+		return item.StatusOptionID
+	}
+	name, ok := item.Fields[f.Name]
+	if !ok {
+		return NoValueOptionID
+	}
+	for _, opt := range f.Options {
+		if opt.Name == name {
+			return opt.ID
+		}
+	}
+	return NoValueOptionID
+}
@@ -0,0 +1,177 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func sampleProject() *gh.Project {
+	status := gh.SingleSelectField{
+		ID:   "F_status",
+		Name: "Status",
+		Options: []gh.SingleSelectOption{
+			{ID: "todo", Name: "Todo"},
+			{ID: "done", Name: "Done"},
+		},
+	}
+	priority := gh.SingleSelectField{
+		ID:   "F_prio",
+		Name: "Priority",
+		Options: []gh.SingleSelectOption{
+			{ID: "p0", Name: "P0"},
+			{ID: "p1", Name: "P1"},
+		},
+	}
+	return &gh.Project{
+		ID:     "P",
+		Status: status,
+		Fields: []gh.SingleSelectField{status, priority},
+		Items: []gh.Item{
+			{ID: "i1", Title: "Fix login", StatusOptionID: "todo", ContentType: gh.ContentIssue,
+				Assignees: []string{"alice"}, Labels: []string{"bug"},
+				Fields: map[string]string{"Status": "Todo", "Priority": "P1"}},
+			{ID: "i2", Title: "Ship release", StatusOptionID: "done", ContentType: gh.ContentPullRequest,
+				Repository: "acme/app",
+				Fields:     map[string]string{"Status": "Done", "Priority": "P0"}},
+			{ID: "i3", Title: "Write docs", StatusOptionID: "", ContentType: gh.ContentDraftIssue},
+		},
+	}
+}
+
+func ids(items []gh.Item) []string {
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		out = append(out, it.ID)
+	}
+	return out
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+	p := sampleProject()
+
+	cases := []struct {
+		field string
+		want  map[string][]string
+		order []string
+	}{
+		{"", map[string][]string{"Todo": {"i1"}, "Done": {"i2"}, "No Status": {"i3"}}, []string{"Todo", "Done", "No Status"}},
+		{"priority", map[string][]string{"P0": {"i2"}, "P1": {"i1"}, "No Priority": {"i3"}}, []string{"P0", "P1", "No Priority"}},
+	}
+	for _, tc := range cases {
+		cols, err := Group(p, tc.field)
+		if err != nil {
+			t.Fatalf("Group(%q): %v", tc.field, err)
+		}
+		var order []string
+		got := map[string][]string{}
+		for _, c := range cols {
+			order = append(order, c.Name)
+			got[c.Name] = ids(c.Items)
+		}
+		if diff := cmp.Diff(tc.order, order); diff != "" {
+			t.Errorf("Group(%q) column order (-want +got):\n%s", tc.field, diff)
+		}
+		if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Errorf("Group(%q) items (-want +got):\n%s", tc.field, diff)
+		}
+	}
+
+	if _, err := Group(p, "Iteration"); err == nil {
+		t.Fatal("expected an error grouping by an unknown field")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	items := sampleProject().Items
+
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"i1", "i2", "i3"}},
+		{"assignee:alice", []string{"i1"}},
+		{"assignee:@alice", []string{"i1"}},
+		{"-assignee:alice", []string{"i2", "i3"}},
+		{"label:BUG", []string{"i1"}},
+		{"type:pr", []string{"i2"}},
+		{"repo:acme/app", []string{"i2"}},
+		{"status:done", []string{"i2"}},
+		{"no:status", []string{"i3"}},
+		{`priority:"P1"`, []string{"i1"}},
+		{"docs", []string{"i3"}},
+		{"-status:Done type:issue", []string{"i1"}},
+	}
+	for _, tc := range cases {
+		f, err := ParseFilter(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", tc.expr, err)
+		}
+		if diff := cmp.Diff(tc.want, ids(f.Apply(items))); diff != "" {
+			t.Errorf("filter %q (-want +got):\n%s", tc.expr, diff)
+		}
+	}
+
+	for _, bad := range []string{`status:"In Progress`, "label:"} {
+		if _, err := ParseFilter(bad); err == nil {
+			t.Errorf("ParseFilter(%q) should fail", bad)
+		}
+	}
+}
+
+func TestFilterUsesFields(t *testing.T) {
+	t.Parallel()
+	cases := map[string]bool{
+		"":                           false,
+		"assignee:alice label:bug":   false,
+		"repo:acme/app -type:pr fix": false,
+		"no:assignee no:label":       false,
+		"status:Todo":                true,
+		"-priority:P1":               true,
+		"no:iteration":               true,
+	}
+	for in, want := range cases {
+		f, err := ParseFilter(in)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", in, err)
+		}
+		if got := f.UsesFields(); got != want {
+			t.Errorf("ParseFilter(%q).UsesFields() = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+	items := sampleProject().Items
+	items[0].Number, items[1].Number, items[2].Number = 3, 1, 2
+
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"", []string{"i1", "i2", "i3"}},
+		{"number", []string{"i2", "i3", "i1"}},
+		{"-number", []string{"i1", "i3", "i2"}},
+		{"title", []string{"i1", "i2", "i3"}},
+		{"-title", []string{"i3", "i2", "i1"}},
+		{"priority", []string{"i2", "i1", "i3"}},
+		{"-Priority", []string{"i1", "i2", "i3"}},
+	}
+	for _, tc := range cases {
+		o, err := ParseSort(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseSort(%q): %v", tc.expr, err)
+		}
+		if diff := cmp.Diff(tc.want, ids(o.Apply(items))); diff != "" {
+			t.Errorf("sort %q (-want +got):\n%s", tc.expr, diff)
+		}
+	}
+	if _, err := ParseSort("-"); err == nil {
+		t.Error(`ParseSort("-") should fail`)
+	}
+}
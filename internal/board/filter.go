@@ -0,0 +1,192 @@
+package board
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// Filter is a parsed filter expression. Terms are ANDed; the zero value
+// matches everything.
+//
+// Supported terms, loosely modelled on the GitHub Projects filter bar:
+//
+//	assignee:LOGIN   label:NAME   repo:OWNER/NAME   type:issue|pr|draft
+//	no:assignee      no:label     no:FIELD
+//	FIELD:VALUE      any project field by name, e.g. status:Todo
+//	WORD             case-insensitive substring of the title
+//
+// Prefix a term with "-" to negate it. Values containing spaces can be
+// double-quoted: status:"In Progress".
+type Filter struct {
+	raw   string
+	terms []term
+}
+
+type term struct {
+	key    string // "" for a bare title word
+	value  string
+	negate bool
+}
+
+func ParseFilter(s string) (Filter, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return Filter{}, err
+	}
+	f := Filter{raw: strings.TrimSpace(s)}
+	for _, tok := range tokens {
+		t := term{}
+		if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+			t.negate = true
+			tok = tok[1:]
+		}
+		if k, v, ok := strings.Cut(tok, ":"); ok && k != "" {
+			t.key = strings.ToLower(k)
+			t.value = strings.Trim(v, `"`)
+		} else {
+			t.value = strings.Trim(tok, `"`)
+		}
+		if t.value == "" {
+			return Filter{}, fmt.Errorf("filter term %q has no value", tok)
+		}
+		f.terms = append(f.terms, t)
+	}
+	return f, nil
+}
+
+// tokenize splits on whitespace, keeping double-quoted runs together.
+func tokenize(s string) ([]string, error) {
+	var (
+		tokens []string
+		cur    strings.Builder
+		quoted bool
+	)
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			cur.WriteRune(r)
+		case (r == ' ' || r == '\t') && !quoted:
+			if cur.Len() > 0 {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in filter %q", s)
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens, nil
+}
+
+func (f Filter) Empty() bool { return len(f.terms) == 0 }
+
+// UsesFields reports whether any term reads a project field value (FIELD:VALUE
+// or no:FIELD), so loaders know they must fetch field values.
+func (f Filter) UsesFields() bool {
+	for _, t := range f.terms {
+		switch t.key {
+		case "", "assignee", "label", "repo", "type":
+		case "no":
+			if v := strings.ToLower(t.value); v != "assignee" && v != "label" {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func (f Filter) String() string { return f.raw }
+
+func (f Filter) Match(item gh.Item) bool {
+	for _, t := range f.terms {
+		if t.match(item) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the items matching f, preserving order.
+func (f Filter) Apply(items []gh.Item) []gh.Item {
+	if f.Empty() {
+		return items
+	}
+	out := make([]gh.Item, 0, len(items))
+	for _, it := range items {
+		if f.Match(it) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+func (t term) match(item gh.Item) bool {
+	switch t.key {
+	case "":
+		return containsFold(item.Title, t.value)
+	case "assignee":
+		return anyEqualFold(item.Assignees, strings.TrimPrefix(t.value, "@"))
+	case "label":
+		return anyEqualFold(item.Labels, t.value)
+	case "repo":
+		return strings.EqualFold(item.Repository, t.value)
+	case "type":
+		return matchType(item.ContentType, t.value)
+	case "no":
+		switch strings.ToLower(t.value) {
+		case "assignee":
+			return len(item.Assignees) == 0
+		case "label":
+			return len(item.Labels) == 0
+		}
+		_, ok := fieldValue(item, t.value)
+		return !ok
+	default:
+		v, ok := fieldValue(item, t.key)
+		return ok && strings.EqualFold(v, t.value)
+	}
+}
+
+func matchType(ct gh.ItemContentType, want string) bool {
+	switch strings.ToLower(want) {
+	case "issue":
+		return ct == gh.ContentIssue
+	case "pr", "pullrequest", "pull-request":
+		return ct == gh.ContentPullRequest
+	case "draft", "draftissue":
+		return ct == gh.ContentDraftIssue
+	}
+	return false
+}
+
+func fieldValue(item gh.Item, name string) (string, bool) {
+	for k, v := range item.Fields {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func containsFold(s, sub string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(sub))
+}
+
+func anyEqualFold(list []string, want string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, want) {
+			return true
+		}
+	}
+	return false
+}
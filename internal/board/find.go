@@ -0,0 +1,190 @@
+package board
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// FindItems returns the items referenced by ref, which may be:
+//
+//	PVTI_…                  a project item node id
+//	https://github.com/…    the issue / pull request URL
+//	owner/repo#123          a number qualified by repository
+//	#123 or 123             a bare number (may match several repositories)
+//
+// More than one result means the reference is ambiguous.
+func FindItems(items []gh.Item, ref string) []gh.Item {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil
+	}
+
+	var match func(gh.Item) bool
+	switch {
+	case strings.HasPrefix(ref, "PVTI_"):
+		match = func(it gh.Item) bool { return it.ID == ref }
+	case strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://"):
+		want := normalizeURL(ref)
+		match = func(it gh.Item) bool { return it.URL != "" && normalizeURL(it.URL) == want }
+	default:
+		repo, num, ok := parseNumberRef(ref)
+		if !ok {
+			return nil
+		}
+		match = func(it gh.Item) bool {
+			return it.Number == num && (repo == "" || strings.EqualFold(it.Repository, repo))
+		}
+	}
+
+	var out []gh.Item
+	for _, it := range items {
+		if match(it) {
+			out = append(out, it)
+		}
+	}
+	return out
+}
+
+// normalizeURL drops the query, fragment and trailing slash so a URL copied
+// from the browser (".../issues/12#issuecomment-1") still matches.
+func normalizeURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return strings.TrimRight(raw, "/")
+	}
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.Host = strings.ToLower(u.Host)
+	return strings.TrimRight(u.String(), "/")
+}
+
+func parseNumberRef(ref string) (string, int, bool) {
+	repo := ""
+	if i := strings.LastIndexByte(ref, '#'); i >= 0 {
+		repo, ref = ref[:i], ref[i+1:]
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return repo, n, true
+}
+
+// MatchName resolves want against names (column, option or iteration names).
+// An exact case-insensitive match wins; otherwise every name containing want
+// is returned. The result holds indices into names: none means no match, more
+// than one means ambiguous.
+func MatchName(names []string, want string) []int {
+	want = strings.TrimSpace(want)
+	var exact, partial []int
+	for i, n := range names {
+		switch {
+		case strings.EqualFold(n, want):
+			exact = append(exact, i)
+		case containsFold(n, want):
+			partial = append(partial, i)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+// SearchItems ranks items for an interactive finder. A query FindItems
+// understands (a URL, #123, owner/repo#123 or an item id) puts its matches
+// first. Every whitespace-separated term must then fuzzily match the item's
+// "#number title repo @assignee…" line: its letters in order, with runs and
+// word starts scoring higher. Ties keep the items' order. An empty query
+// returns items unchanged.
+func SearchItems(items []gh.Item, query string) []gh.Item {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return items
+	}
+	exact := FindItems(items, query)
+	seen := make(map[string]bool, len(exact))
+	for _, it := range exact {
+		seen[it.ID] = true
+	}
+
+	type scored struct {
+		item  gh.Item
+		score int
+	}
+	var ranked []scored
+	for _, it := range items {
+		if seen[it.ID] {
+			continue
+		}
+		line := strings.ToLower(searchLine(it))
+		total := 0
+		for _, t := range terms {
+			s, ok := fuzzyScore(line, t)
+			if !ok {
+				total = -1
+				break
+			}
+			total += s
+		}
+		if total >= 0 {
+			ranked = append(ranked, scored{it, total})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	out := append([]gh.Item(nil), exact...)
+	for _, r := range ranked {
+		out = append(out, r.item)
+	}
+	return out
+}
+
+func searchLine(it gh.Item) string {
+	var b strings.Builder
+	if it.Number > 0 {
+		b.WriteString("#" + strconv.Itoa(it.Number) + " ")
+	}
+	b.WriteString(it.Title)
+	if it.Repository != "" {
+		b.WriteString(" " + it.Repository)
+	}
+	for _, a := range it.Assignees {
+		b.WriteString(" @" + a)
+	}
+	return b.String()
+}
+
+// fuzzyScore matches needle's runes in order within hay. Each match scores
+// a point, more when it continues a run or starts a word, and a little less
+// the later the match begins. "#" doesn't start a word, so "7" prefers a
+// title's "7" over "#7"; "#7" itself matches the number.
+func fuzzyScore(hay, needle string) (int, bool) {
+	h := []rune(hay)
+	score, pos, first, prev := 0, 0, -1, -2
+	for _, r := range needle {
+		i := pos
+		for i < len(h) && h[i] != r {
+			i++
+		}
+		if i == len(h) {
+			return 0, false
+		}
+		score++
+		if i == prev+1 {
+			score += 4
+		}
+		if i == 0 || strings.ContainsRune(" /-_.@", h[i-1]) {
+			score += 3
+		}
+		if first < 0 {
+			first = i
+		}
+		prev, pos = i, i+1
+	}
+	return score - min(first, 20)/5, true
+}
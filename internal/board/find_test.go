@@ -0,0 +1,95 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func TestFindItems(t *testing.T) {
+	t.Parallel()
+	items := []gh.Item{
+		{ID: "PVTI_a", Number: 12, Repository: "acme/app", URL: "https://github.com/acme/app/issues/12"},
+		{ID: "PVTI_b", Number: 12, Repository: "acme/api", URL: "https://github.com/acme/api/pull/12"},
+		{ID: "PVTI_c", Number: 7, Repository: "acme/app", URL: "https://github.com/acme/app/issues/7"},
+		{ID: "PVTI_d", Title: "draft"},
+	}
+
+	cases := []struct {
+		ref  string
+		want []string
+	}{
+		{"PVTI_c", []string{"PVTI_c"}},
+		{"https://github.com/acme/app/issues/12", []string{"PVTI_a"}},
+		{"https://GitHub.com/acme/app/issues/12#issuecomment-99", []string{"PVTI_a"}},
+		{"acme/api#12", []string{"PVTI_b"}},
+		{"#12", []string{"PVTI_a", "PVTI_b"}},
+		{"7", []string{"PVTI_c"}},
+		{"#999", nil},
+		{"not-a-ref", nil},
+	}
+	for _, tc := range cases {
+		got := FindItems(items, tc.ref)
+		var ids []string
+		for _, it := range got {
+			ids = append(ids, it.ID)
+		}
+		if diff := cmp.Diff(tc.want, ids); diff != "" {
+			t.Errorf("FindItems(%q) (-want +got):\n%s", tc.ref, diff)
+		}
+	}
+}
+
+func TestMatchName(t *testing.T) {
+	t.Parallel()
+	names := []string{"Todo", "In Progress", "In Review", "Done"}
+	cases := []struct {
+		want string
+		got  []int
+	}{
+		{"done", []int{3}},
+		{"review", []int{2}},
+		{"in", []int{1, 2}},
+		{"Blocked", nil},
+	}
+	for _, tc := range cases {
+		if diff := cmp.Diff(tc.got, MatchName(names, tc.want)); diff != "" {
+			t.Errorf("MatchName(%q) (-want +got):\n%s", tc.want, diff)
+		}
+	}
+}
+
+func TestSearchItems(t *testing.T) {
+	t.Parallel()
+	items := []gh.Item{
+		{ID: "a", Number: 12, Title: "Fix login redirect", Repository: "acme/app", Assignees: []string{"alice"}},
+		{ID: "b", Number: 120, Title: "Flaky integration tests", Repository: "acme/api"},
+		{ID: "c", Number: 7, Title: "Login page copy", Repository: "acme/web", Assignees: []string{"bob"}},
+		{ID: "d", Title: "Draft: release notes"},
+		{ID: "e", Number: 3, Title: "Lint config", Repository: "acme/app", URL: "https://github.com/acme/app/issues/3"},
+	}
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"a", "b", "c", "d", "e"}},
+		{"login", []string{"c", "a"}},
+		{"login @alice", []string{"a"}},
+		{"#12", []string{"a", "b"}},
+		{"api flaky", []string{"b"}},
+		{"rel nts", []string{"d"}},
+		{"https://github.com/acme/app/issues/3", []string{"e"}},
+		{"zzz", nil},
+	}
+	for _, tc := range cases {
+		var ids []string
+		for _, it := range SearchItems(items, tc.query) {
+			ids = append(ids, it.ID)
+		}
+		if diff := cmp.Diff(tc.want, ids); diff != "" {
+			t.Errorf("SearchItems(%q) (-want +got):\n%s", tc.query, diff)
+		}
+	}
+}
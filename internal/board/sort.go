@@ -0,0 +1,102 @@
+package board
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// Sort orders the items within each column. The zero value keeps GitHub's
+// order (the project's manual ordering).
+type Sort struct {
+	key  string
+	desc bool
+}
+
+// sortKeys are the supported keys; anything else is taken as a project field
+// name and compared as text.
+var sortKeys = []string{"number", "title", "updated", "repo"}
+
+// ParseSort parses "KEY" or "-KEY" (descending), where KEY is number, title,
+// updated, repo or a project field name.
+func ParseSort(s string) (Sort, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Sort{}, nil
+	}
+	var o Sort
+	if strings.HasPrefix(s, "-") {
+		o.desc = true
+		s = s[1:]
+	}
+	if s == "" {
+		return Sort{}, fmt.Errorf("sort key missing; use one of %s or a field name", strings.Join(sortKeys, ", "))
+	}
+	o.key = strings.ToLower(s)
+	return o, nil
+}
+
+func (o Sort) Empty() bool { return o.key == "" }
+
+// UsesFields reports whether the sort reads a project field value.
+func (o Sort) UsesFields() bool {
+	return o.key != "" && !slices.Contains(sortKeys, o.key)
+}
+
+func (o Sort) String() string {
+	if o.desc {
+		return "-" + o.key
+	}
+	return o.key
+}
+
+// Apply returns the items in sort order. Ties, and items lacking the field,
+// keep their relative order, the latter after the others.
+func (o Sort) Apply(items []gh.Item) []gh.Item {
+	if o.Empty() {
+		return items
+	}
+	out := slices.Clone(items)
+	slices.SortStableFunc(out, func(a, b gh.Item) int {
+		c := o.compare(a, b)
+		if o.desc {
+			c = -c
+		}
+		return c
+	})
+	return out
+}
+
+func (o Sort) compare(a, b gh.Item) int {
+	switch o.key {
+	case "number":
+		return cmp.Compare(a.Number, b.Number)
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "updated":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case "repo":
+		return strings.Compare(strings.ToLower(a.Repository), strings.ToLower(b.Repository))
+	}
+	av, aok := fieldValue(a, o.key)
+	bv, bok := fieldValue(b, o.key)
+	if !aok || !bok {
+		// Missing values sort last whichever the direction.
+		c := cmp.Compare(boolRank(aok), boolRank(bok))
+		if o.desc {
+			c = -c
+		}
+		return c
+	}
+	return strings.Compare(strings.ToLower(av), strings.ToLower(bv))
+}
+
+func boolRank(ok bool) int {
+	if ok {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,243 @@
+// Package cache keeps the last loaded board on disk so the TUI can paint it
+// instantly on startup and browse it offline.
+//
+// Layout under the user cache dir (e.g. ~/.cache/gh-kanban):
+//
+//	projects/<project ID>.json   one snapshot per project
+//	columns/<project ID>.json    local column order, hidden and collapsed
+//	index.json                   owner + spec -> project ID
+//
+// Snapshots are keyed by project ID because that is the only stable
+// identity; the index lets a later launch with -N or -p find it before any
+// network call.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// formatVersion is bumped whenever gh.Project changes incompatibly; older
+// snapshots are then treated as missing.
+const formatVersion = 1
+
+var ErrNotFound = errors.New("no cached snapshot")
+
+type Store struct {
+	dir string
+}
+
+// Open returns the store under os.UserCacheDir()/gh-kanban.
+func Open() (*Store, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("cache dir: %w", err)
+	}
+	return New(filepath.Join(base, "gh-kanban")), nil
+}
+
+// New returns a store rooted at dir. The directory is created on first save.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Snapshot is one cached project as written to disk.
+type Snapshot struct {
+	Version int
+	SavedAt time.Time
+	Project *gh.Project
+}
+
+// Handle is a Store bound to one owner and project spec.
+type Handle struct {
+	store     *Store
+	ownerType gh.ClientType
+	login     string
+	spec      gh.ProjectSpec
+}
+
+func (s *Store) For(ownerType gh.ClientType, login string, spec gh.ProjectSpec) *Handle {
+	return &Handle{store: s, ownerType: ownerType, login: login, spec: spec}
+}
+
+// Load returns the snapshot for the handle's spec, or ErrNotFound.
+func (h *Handle) Load() (*Snapshot, error) {
+	index, err := h.store.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	id, ok := index[h.key(h.spec)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	data, err := os.ReadFile(h.store.projectPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("decode cached snapshot: %w", err)
+	}
+	if snap.Version != formatVersion || snap.Project == nil {
+		return nil, ErrNotFound
+	}
+	return &snap, nil
+}
+
+// Save writes p and points both the handle's spec and the project's number
+// at it, so a launch by title and a later launch by -N share one snapshot.
+func (h *Handle) Save(p *gh.Project, savedAt time.Time) error {
+	if p == nil || p.ID == "" {
+		return errors.New("cache: project has no ID")
+	}
+	data, err := json.Marshal(Snapshot{Version: formatVersion, SavedAt: savedAt, Project: p})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(h.store.projectPath(p.ID), data); err != nil {
+		return err
+	}
+
+	index, err := h.store.readIndex()
+	if err != nil {
+		index = map[string]string{} // a corrupt index is rebuilt, not fatal
+	}
+	index[h.key(h.spec)] = p.ID
+	if p.Number > 0 {
+		index[h.key(gh.ProjectSpec{Number: p.Number})] = p.ID
+	}
+	data, err = json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(h.store.indexPath(), data)
+}
+
+// Columns is the board's local column layout, by option ID. It only ever
+// lives on this machine; GitHub's option order is left alone.
+type Columns struct {
+	// Order lists option IDs in display order. Options it doesn't name
+	// follow in GitHub's order.
+	Order     []string `json:",omitempty"`
+	Hidden    []string `json:",omitempty"`
+	Collapsed []string `json:",omitempty"`
+}
+
+func (c Columns) Empty() bool {
+	return len(c.Order) == 0 && len(c.Hidden) == 0 && len(c.Collapsed) == 0
+}
+
+// LoadColumns returns the saved column layout of a project; none saved is
+// the zero Columns. Layouts are kept by the Store rather than a Handle, so
+// they survive running without the snapshot cache.
+func (s *Store) LoadColumns(projectID string) (Columns, error) {
+	var c Columns
+	data, err := os.ReadFile(s.columnsPath(projectID))
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Columns{}, fmt.Errorf("decode column layout: %w", err)
+	}
+	return c, nil
+}
+
+// SaveColumns stores a project's column layout; an empty one removes it.
+func (s *Store) SaveColumns(projectID string, c Columns) error {
+	path := s.columnsPath(projectID)
+	if c.Empty() {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// key is e.g. "organization/acme/7" or "user/alice/title:Sprint Backlog".
+// Number wins over title, matching gh.ProjectSpec.
+func (h *Handle) key(spec gh.ProjectSpec) string {
+	sel := "title:" + spec.Title
+	if spec.Number > 0 {
+		sel = strconv.Itoa(spec.Number)
+	}
+	return string(h.ownerType) + "/" + strings.ToLower(h.login) + "/" + sel
+}
+
+func (s *Store) indexPath() string {
+	return filepath.Join(s.dir, "index.json")
+}
+
+func (s *Store) projectPath(id string) string {
+	return filepath.Join(s.dir, "projects", safeName(id)+".json")
+}
+
+func (s *Store) columnsPath(id string) string {
+	return filepath.Join(s.dir, "columns", safeName(id)+".json")
+}
+
+func (s *Store) readIndex() (map[string]string, error) {
+	data, err := os.ReadFile(s.indexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]string{}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("decode cache index: %w", err)
+	}
+	return index, nil
+}
+
+// safeName keeps node IDs (PVT_kwDO…) usable as file names on every OS.
+func safeName(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// writeFileAtomic writes via a temp file and rename so a crash mid-write
+// never leaves a truncated snapshot behind.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
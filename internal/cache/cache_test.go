@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func sampleProject() *gh.Project {
+	return &gh.Project{
+		ID: "PVT_kwDOA/1", Title: "Sprint", Number: 7,
+		Status: gh.SingleSelectField{ID: "F", Name: "Status", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}}},
+		Items: []gh.Item{{
+			ID: "i1", Title: "A", StatusOptionID: "todo",
+			Assignees: []string{"alice"}, Fields: map[string]string{"Status": "Todo"},
+		}},
+	}
+}
+
+func TestHandle_RoundTrip(t *testing.T) {
+	t.Parallel()
+	store := New(t.TempDir())
+	h := store.For(gh.ClientTypeOrganization, "Acme", gh.ProjectSpec{Title: "Sprint"})
+	savedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := h.Save(sampleProject(), savedAt); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := h.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(sampleProject(), snap.Project); diff != "" {
+		t.Fatalf("snapshot mismatch (-want +got):\n%s", diff)
+	}
+	if !snap.SavedAt.Equal(savedAt) {
+		t.Fatalf("SavedAt = %v, want %v", snap.SavedAt, savedAt)
+	}
+
+	// Saved by title, found again by number and with a differently-cased login.
+	byNumber := store.For(gh.ClientTypeOrganization, "acme", gh.ProjectSpec{Number: 7})
+	if _, err := byNumber.Load(); err != nil {
+		t.Fatalf("load by number: %v", err)
+	}
+}
+
+func TestHandle_LoadMissing(t *testing.T) {
+	t.Parallel()
+	store := New(t.TempDir())
+	if err := store.For(gh.ClientTypeUser, "alice", gh.ProjectSpec{Number: 1}).Save(sampleProject(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]*Handle{
+		"other owner type": store.For(gh.ClientTypeOrganization, "alice", gh.ProjectSpec{Number: 7}),
+		"other number":     store.For(gh.ClientTypeUser, "alice", gh.ProjectSpec{Number: 8}),
+		"empty store":      New(t.TempDir()).For(gh.ClientTypeUser, "alice", gh.ProjectSpec{Number: 7}),
+	}
+	for name, h := range cases {
+		if _, err := h.Load(); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: err = %v, want ErrNotFound", name, err)
+		}
+	}
+}
+
+func TestHandle_IgnoresOldFormat(t *testing.T) {
+	t.Parallel()
+	store := New(t.TempDir())
+	h := store.For(gh.ClientTypeUser, "alice", gh.ProjectSpec{Number: 7})
+	if err := h.Save(sampleProject(), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(store.projectPath("PVT_kwDOA/1"), []byte(`{"Version":0,"Project":{"ID":"x"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Load(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestStore_Columns(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	store := New(dir)
+
+	if c, err := store.LoadColumns("PVT_1"); err != nil || !c.Empty() {
+		t.Fatalf("LoadColumns before any save = %+v, %v; want empty", c, err)
+	}
+	want := Columns{Order: []string{"done", "todo"}, Collapsed: []string{"done"}}
+	if err := store.SaveColumns("PVT_1", want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := New(dir).LoadColumns("PVT_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("columns (-want +got):\n%s", diff)
+	}
+
+	if err := store.SaveColumns("PVT_1", Columns{}); err != nil {
+		t.Fatal(err)
+	}
+	if c, _ := store.LoadColumns("PVT_1"); !c.Empty() {
+		t.Fatalf("after saving an empty layout: %+v", c)
+	}
+}
@@ -1,5 +1,9 @@
 package cli
 
 type CLI struct {
-	View ViewCmd `cmd:"" default:"withargs" help:"Open a Projects v2 board in the terminal."`
+	View   ViewCmd   `cmd:"" default:"withargs" help:"Open a Projects v2 board in the terminal."`
+	Export ExportCmd `cmd:"" help:"Dump a Projects v2 board to stdout as JSON, CSV, NDJSON or Markdown."`
+	Print  PrintCmd  `cmd:"" help:"Print a static snapshot of a board to stdout (no TTY needed)."`
+	Move   MoveCmd   `cmd:"" help:"Move a card to another Status column (for scripts and CI)."`
+	Set    SetCmd    `cmd:"" help:"Set a project field on a card (for scripts and CI)."`
 }
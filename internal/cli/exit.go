@@ -0,0 +1,28 @@
+package cli
+
+import "fmt"
+
+// Exit codes for the scripting commands, so CI can tell "the card reference
+// was ambiguous" from a network or auth failure (which exit 1).
+const (
+	ExitAmbiguous = 2
+	ExitNotFound  = 3
+)
+
+// ExitError carries a specific process exit code up to main.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+func ambiguous(format string, args ...any) error {
+	return &ExitError{Code: ExitAmbiguous, Err: fmt.Errorf(format, args...)}
+}
+
+func notFound(format string, args ...any) error {
+	return &ExitError{Code: ExitNotFound, Err: fmt.Errorf(format, args...)}
+}
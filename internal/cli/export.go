@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+type ExportCmd struct {
+	ProjectFlags `embed:""`
+
+	Format  string `short:"f" enum:"json,csv,ndjson,markdown" default:"json" help:"Output format: json, csv, ndjson or markdown (one table per column)."`
+	Filter  string `help:"Only export matching items, e.g. 'assignee:alice label:bug -status:Done'."`
+	GroupBy string `name:"group-by" short:"g" help:"SingleSelect field to group columns by. Defaults to Status."`
+}
+
+func (c *ExportCmd) Run() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	filter, err := board.ParseFilter(c.Filter)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
+	spec, err := resolveSpecNonInteractive(client, c.spec())
+	if err != nil {
+		return err
+	}
+	project, err := client.LoadProject(spec)
+	if err != nil {
+		return err
+	}
+
+	columns, err := board.Group(project, c.GroupBy)
+	if err != nil {
+		return err
+	}
+	for i := range columns {
+		columns[i].Items = filter.Apply(columns[i].Items)
+	}
+	field, _ := board.GroupField(project, c.GroupBy)
+
+	return writeExport(os.Stdout, c.Format, exportBoardOf(project, field.Name, filter.String(), columns))
+}
+
+type exportProject struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+type exportItem struct {
+	Column     string            `json:"column,omitempty"` // set for ndjson only
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	Number     int               `json:"number,omitempty"`
+	Title      string            `json:"title"`
+	URL        string            `json:"url,omitempty"`
+	Repository string            `json:"repository,omitempty"`
+	Assignees  []string          `json:"assignees"`
+	Labels     []string          `json:"labels"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type exportColumn struct {
+	Name  string       `json:"name"`
+	Count int          `json:"count"`
+	Items []exportItem `json:"items"`
+}
+
+type exportBoard struct {
+	Project exportProject  `json:"project"`
+	GroupBy string         `json:"groupBy"`
+	Filter  string         `json:"filter,omitempty"`
+	Columns []exportColumn `json:"columns"`
+}
+
+func exportBoardOf(p *gh.Project, groupBy, filter string, columns []board.Column) exportBoard {
+	out := exportBoard{
+		Project: exportProject{ID: p.ID, Title: p.Title, Number: p.Number, URL: p.URL},
+		GroupBy: groupBy,
+		Filter:  filter,
+		Columns: make([]exportColumn, 0, len(columns)),
+	}
+	for _, col := range columns {
+		ec := exportColumn{Name: col.Name, Count: len(col.Items), Items: make([]exportItem, 0, len(col.Items))}
+		for _, it := range col.Items {
+			ec.Items = append(ec.Items, exportItem{
+				ID:         it.ID,
+				Type:       string(it.ContentType),
+				Number:     it.Number,
+				Title:      it.Title,
+				URL:        it.URL,
+				Repository: it.Repository,
+				Assignees:  nonNil(it.Assignees),
+				Labels:     nonNil(it.Labels),
+				Fields:     it.Fields,
+				Body:       it.Body,
+			})
+		}
+		out.Columns = append(out.Columns, ec)
+	}
+	return out
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func writeExport(w io.Writer, format string, b exportBoard) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(b)
+	case "ndjson":
+		return writeNDJSON(w, b)
+	case "csv":
+		return writeCSV(w, b)
+	case "markdown":
+		return writeMarkdown(w, b)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+func writeNDJSON(w io.Writer, b exportBoard) error {
+	enc := json.NewEncoder(w)
+	for _, col := range b.Columns {
+		for _, it := range col.Items {
+			it.Column = col.Name
+			if err := enc.Encode(it); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeCSV emits one row per item. Besides the fixed columns, every project
+// field that has a value on at least one item gets its own column, sorted by
+// name, so spreadsheets can pivot on Priority, Iteration, etc. The group-by
+// field already is the first column.
+func writeCSV(w io.Writer, b exportBoard) error {
+	fieldSet := map[string]struct{}{}
+	for _, col := range b.Columns {
+		for _, it := range col.Items {
+			for k := range it.Fields {
+				if k != b.GroupBy {
+					fieldSet[k] = struct{}{}
+				}
+			}
+		}
+	}
+	fields := make([]string, 0, len(fieldSet))
+	for k := range fieldSet {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	cw := csv.NewWriter(w)
+	header := []string{b.GroupBy, "type", "number", "title", "repository", "url", "assignees", "labels"}
+	if err := cw.Write(append(header, fields...)); err != nil {
+		return err
+	}
+	for _, col := range b.Columns {
+		for _, it := range col.Items {
+			number := ""
+			if it.Number > 0 {
+				number = strconv.Itoa(it.Number)
+			}
+			row := []string{col.Name, it.Type, number, it.Title, it.Repository, it.URL,
+				strings.Join(it.Assignees, ";"), strings.Join(it.Labels, ";")}
+			for _, f := range fields {
+				row = append(row, it.Fields[f])
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeMarkdown(w io.Writer, b exportBoard) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s (#%d)\n", b.Project.Title, b.Project.Number)
+	if b.Filter != "" {
+		fmt.Fprintf(&sb, "\nFilter: `%s`\n", b.Filter)
+	}
+	for _, col := range b.Columns {
+		fmt.Fprintf(&sb, "\n## %s (%d)\n\n", col.Name, col.Count)
+		if len(col.Items) == 0 {
+			sb.WriteString("_(empty)_\n")
+			continue
+		}
+		sb.WriteString("| # | Title | Assignees | Labels |\n")
+		sb.WriteString("| --- | --- | --- | --- |\n")
+		for _, it := range col.Items {
+			ref := ""
+			if it.Number > 0 {
+				ref = fmt.Sprintf("%s#%d", it.Repository, it.Number)
+			}
+			title := mdCell(it.Title)
+			if it.URL != "" {
+				title = fmt.Sprintf("[%s](%s)", title, it.URL)
+			}
+			assignees := ""
+			if len(it.Assignees) > 0 {
+				assignees = "@" + strings.Join(it.Assignees, " @")
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", ref, title, mdCell(assignees), mdCell(strings.Join(it.Labels, ", ")))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// mdCell keeps a value from breaking out of its Markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
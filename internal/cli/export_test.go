@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func sampleExport(t *testing.T) exportBoard {
+	t.Helper()
+	p := &gh.Project{
+		ID: "P_1", Title: "Roadmap", Number: 7, URL: "https://github.com/orgs/acme/projects/7",
+		Status: gh.SingleSelectField{
+			ID: "F", Name: "Status",
+			Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}, {ID: "done", Name: "Done"}},
+		},
+		Items: []gh.Item{
+			{ID: "i1", Title: "Fix | pipe", Number: 3, Repository: "acme/app", ContentType: gh.ContentIssue,
+				URL: "https://github.com/acme/app/issues/3", StatusOptionID: "todo",
+				Assignees: []string{"alice"}, Fields: map[string]string{"Status": "Todo", "Estimate": "3"}},
+		},
+	}
+	cols, err := board.Group(p, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return exportBoardOf(p, "Status", "", cols)
+}
+
+func TestWriteExport(t *testing.T) {
+	t.Parallel()
+	b := sampleExport(t)
+
+	cases := []struct {
+		format string
+		want   []string
+	}{
+		{"json", []string{`"title": "Roadmap"`, `"name": "Todo"`, `"count": 1`, `"name": "Done"`, `"assignees": [`}},
+		{"ndjson", []string{`"column":"Todo"`, `"id":"i1"`}},
+		{"csv", []string{"Status,type,number,title,repository,url,assignees,labels,Estimate\n", "Todo,Issue,3,Fix | pipe,acme/app"}},
+		{"markdown", []string{"# Roadmap (#7)", "## Todo (1)", "| acme/app#3 | [Fix \\| pipe](https://github.com/acme/app/issues/3) | @alice |  |", "## Done (0)", "_(empty)_"}},
+	}
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		if err := writeExport(&buf, tc.format, b); err != nil {
+			t.Fatalf("writeExport(%s): %v", tc.format, err)
+		}
+		for _, w := range tc.want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s output missing %q:\n%s", tc.format, w, buf.String())
+			}
+		}
+	}
+}
+
+func TestWriteExport_NDJSONOneItemPerLine(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := writeExport(&buf, "ndjson", sampleExport(t)); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d:\n%s", len(lines), buf.String())
+	}
+	var it exportItem
+	if err := json.Unmarshal([]byte(lines[0]), &it); err != nil {
+		t.Fatalf("line is not valid JSON: %v", err)
+	}
+	if it.Column != "Todo" || it.Number != 3 {
+		t.Fatalf("unexpected item: %+v", it)
+	}
+}
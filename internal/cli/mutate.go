@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+type MoveCmd struct {
+	ProjectFlags `embed:""`
+
+	Item string `required:"" help:"Card to move: issue/PR URL, owner/repo#123, #123 or a PVTI_ item id."`
+	To   string `required:"" help:"Target column (Status option) name. Case-insensitive; a unique substring is enough."`
+}
+
+type SetCmd struct {
+	ProjectFlags `embed:""`
+
+	Item  string `required:"" help:"Card to update: issue/PR URL, owner/repo#123, #123 or a PVTI_ item id."`
+	Field string `required:"" help:"Project field name, e.g. Priority, Estimate, Iteration."`
+	Value string `help:"New value: option or iteration name, number, YYYY-MM-DD date or text."`
+	Clear bool   `help:"Clear the field instead of setting --value."`
+}
+
+// mutationResult is printed as JSON on success so scripts can chain on it.
+type mutationResult struct {
+	Item    mutationItem `json:"item"`
+	Field   string       `json:"field"`
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Changed bool         `json:"changed"`
+}
+
+type mutationItem struct {
+	ID         string `json:"id"`
+	Number     int    `json:"number,omitempty"`
+	Title      string `json:"title"`
+	URL        string `json:"url,omitempty"`
+	Repository string `json:"repository,omitempty"`
+}
+
+func (c *MoveCmd) Run() error {
+	client, project, item, err := loadItem(c.ProjectFlags, c.Item)
+	if err != nil {
+		return err
+	}
+	if project.Status.ID == "" {
+		return errors.New("project has no Status SingleSelect field")
+	}
+
+	opt, err := matchOption(project.Status.Name, project.Status.Options, c.To)
+	if err != nil {
+		return err
+	}
+	from := optionName(project.Status.Options, item.StatusOptionID)
+
+	res := mutationResult{
+		Item:  itemOf(item),
+		Field: project.Status.Name,
+		From:  from,
+		To:    opt.Name,
+	}
+	if item.StatusOptionID != opt.ID {
+		if err := client.UpdateItemStatus(project.ID, item.ID, project.Status.ID, opt.ID); err != nil {
+			return err
+		}
+		res.Changed = true
+	}
+	return writeJSON(os.Stdout, res)
+}
+
+func (c *SetCmd) Run() error {
+	if c.Clear == (c.Value != "") {
+		return errors.New("specify exactly one of --value or --clear")
+	}
+	client, project, item, err := loadItem(c.ProjectFlags, c.Item)
+	if err != nil {
+		return err
+	}
+
+	fields, err := client.FetchFields(project.ID)
+	if err != nil {
+		return err
+	}
+	field, ok := gh.FindField(fields, c.Field)
+	if !ok {
+		names := make([]string, 0, len(fields))
+		for _, f := range fields {
+			if f.Editable() {
+				names = append(names, f.Name)
+			}
+		}
+		return notFound("no field %q; editable fields: %s", c.Field, strings.Join(names, ", "))
+	}
+	if !field.Editable() {
+		return fmt.Errorf("field %q (%s) cannot be set through the Projects API", field.Name, field.DataType)
+	}
+
+	res := mutationResult{
+		Item:  itemOf(item),
+		Field: field.Name,
+		From:  item.Fields[field.Name],
+	}
+
+	if c.Clear {
+		if res.From != "" {
+			if err := client.ClearItemField(project.ID, item.ID, field.ID); err != nil {
+				return err
+			}
+			res.Changed = true
+		}
+		return writeJSON(os.Stdout, res)
+	}
+
+	value, display, err := parseFieldValue(field, c.Value)
+	if err != nil {
+		return err
+	}
+	res.To = display
+	if res.From != display {
+		if err := client.UpdateItemField(project.ID, item.ID, field.ID, value); err != nil {
+			return err
+		}
+		res.Changed = true
+	}
+	return writeJSON(os.Stdout, res)
+}
+
+// loadItem resolves the project from the shared flags, loads every item and
+// picks the one ref points at.
+func loadItem(flags ProjectFlags, ref string) (*gh.Client, *gh.Project, gh.Item, error) {
+	if err := flags.validate(); err != nil {
+		return nil, nil, gh.Item{}, err
+	}
+	client, err := flags.newClient()
+	if err != nil {
+		return nil, nil, gh.Item{}, err
+	}
+	spec, err := resolveSpecNonInteractive(client, flags.spec())
+	if err != nil {
+		return nil, nil, gh.Item{}, err
+	}
+	project, err := client.LoadProject(spec)
+	if err != nil {
+		return nil, nil, gh.Item{}, err
+	}
+
+	matches := board.FindItems(project.Items, ref)
+	switch len(matches) {
+	case 0:
+		return nil, nil, gh.Item{}, notFound("no item matching %q in project %q", ref, project.Title)
+	case 1:
+		return client, project, matches[0], nil
+	default:
+		refs := make([]string, 0, len(matches))
+		for _, m := range matches {
+			refs = append(refs, fmt.Sprintf("%s#%d", m.Repository, m.Number))
+		}
+		return nil, nil, gh.Item{}, ambiguous("%q matches %d items (%s); qualify it as owner/repo#N or pass the URL", ref, len(matches), strings.Join(refs, ", "))
+	}
+}
+
+func matchOption(field string, options []gh.SingleSelectOption, want string) (gh.SingleSelectOption, error) {
+	names := make([]string, 0, len(options))
+	for _, o := range options {
+		names = append(names, o.Name)
+	}
+	idx := board.MatchName(names, want)
+	switch len(idx) {
+	case 0:
+		return gh.SingleSelectOption{}, notFound("%s has no option %q; options: %s", field, want, strings.Join(names, ", "))
+	case 1:
+		return options[idx[0]], nil
+	default:
+		cands := make([]string, 0, len(idx))
+		for _, i := range idx {
+			cands = append(cands, names[i])
+		}
+		return gh.SingleSelectOption{}, ambiguous("%q matches several %s options: %s", want, field, strings.Join(cands, ", "))
+	}
+}
+
+func optionName(options []gh.SingleSelectOption, id string) string {
+	for _, o := range options {
+		if o.ID == id {
+			return o.Name
+		}
+	}
+	return ""
+}
+
+// parseFieldValue converts the CLI string into a typed update for field and
+// returns it together with the value as GitHub will display it.
+func parseFieldValue(field gh.Field, raw string) (gh.FieldValue, string, error) {
+	switch field.DataType {
+	case gh.FieldText:
+		return gh.FieldValue{Text: &raw}, raw, nil
+	case gh.FieldNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return gh.FieldValue{}, "", fmt.Errorf("field %q expects a number, got %q", field.Name, raw)
+		}
+		return gh.FieldValue{Number: &n}, strconv.FormatFloat(n, 'f', -1, 64), nil
+	case gh.FieldDate:
+		if _, err := time.Parse(time.DateOnly, raw); err != nil {
+			return gh.FieldValue{}, "", fmt.Errorf("field %q expects a YYYY-MM-DD date, got %q", field.Name, raw)
+		}
+		return gh.FieldValue{Date: raw}, raw, nil
+	case gh.FieldSingleSelect:
+		opt, err := matchOption(field.Name, field.Options, raw)
+		if err != nil {
+			return gh.FieldValue{}, "", err
+		}
+		return gh.FieldValue{SingleSelectOptionID: opt.ID}, opt.Name, nil
+	case gh.FieldIteration:
+		names := make([]string, 0, len(field.Iterations))
+		for _, it := range field.Iterations {
+			names = append(names, it.Title)
+		}
+		idx := board.MatchName(names, raw)
+		switch len(idx) {
+		case 0:
+			return gh.FieldValue{}, "", notFound("%s has no iteration %q; iterations: %s", field.Name, raw, strings.Join(names, ", "))
+		case 1:
+			it := field.Iterations[idx[0]]
+			return gh.FieldValue{IterationID: it.ID}, it.Title, nil
+		default:
+			return gh.FieldValue{}, "", ambiguous("%q matches several %s iterations", raw, field.Name)
+		}
+	}
+	return gh.FieldValue{}, "", fmt.Errorf("field %q (%s) cannot be set", field.Name, field.DataType)
+}
+
+func itemOf(it gh.Item) mutationItem {
+	return mutationItem{
+		ID:         it.ID,
+		Number:     it.Number,
+		Title:      it.Title,
+		URL:        it.URL,
+		Repository: it.Repository,
+	}
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
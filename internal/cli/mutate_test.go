@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func TestParseFieldValue(t *testing.T) {
+	t.Parallel()
+	prio := gh.Field{Name: "Priority", DataType: gh.FieldSingleSelect, Options: []gh.SingleSelectOption{
+		{ID: "o1", Name: "P0 – urgent"}, {ID: "o2", Name: "P1"}, {ID: "o3", Name: "P10"},
+	}}
+	sprint := gh.Field{Name: "Sprint", DataType: gh.FieldIteration, Iterations: []gh.Iteration{
+		{ID: "it1", Title: "Sprint 1"}, {ID: "it2", Title: "Sprint 2"},
+	}}
+
+	cases := []struct {
+		name     string
+		field    gh.Field
+		raw      string
+		wantShow string
+		wantCode int // 0 = success, -1 = plain error
+	}{
+		{"text", gh.Field{Name: "Note", DataType: gh.FieldText}, "hello", "hello", 0},
+		{"number", gh.Field{Name: "Estimate", DataType: gh.FieldNumber}, "3.50", "3.5", 0},
+		{"bad number", gh.Field{Name: "Estimate", DataType: gh.FieldNumber}, "three", "", -1},
+		{"date", gh.Field{Name: "Due", DataType: gh.FieldDate}, "2026-10-31", "2026-10-31", 0},
+		{"bad date", gh.Field{Name: "Due", DataType: gh.FieldDate}, "31/10/2026", "", -1},
+		{"option exact beats substring", prio, "p1", "P1", 0},
+		{"option substring", prio, "urgent", "P0 – urgent", 0},
+		{"option ambiguous", prio, "P", "", ExitAmbiguous},
+		{"option missing", prio, "P3", "", ExitNotFound},
+		{"iteration", sprint, "sprint 2", "Sprint 2", 0},
+		{"iteration ambiguous", sprint, "Sprint", "", ExitAmbiguous},
+	}
+	for _, tc := range cases {
+		_, show, err := parseFieldValue(tc.field, tc.raw)
+		switch {
+		case tc.wantCode == 0:
+			if err != nil {
+				t.Errorf("%s: unexpected error %v", tc.name, err)
+			} else if show != tc.wantShow {
+				t.Errorf("%s: display = %q, want %q", tc.name, show, tc.wantShow)
+			}
+		case tc.wantCode < 0:
+			if err == nil {
+				t.Errorf("%s: expected an error", tc.name)
+			}
+		default:
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != tc.wantCode {
+				t.Errorf("%s: err = %v, want exit code %d", tc.name, err, tc.wantCode)
+			}
+		}
+	}
+}
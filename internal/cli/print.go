@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/tui"
+)
+
+type PrintCmd struct {
+	ProjectFlags `embed:""`
+
+	Format     string `short:"f" enum:"plain,ansi,markdown" default:"plain" help:"plain (ASCII borders, no colour), ansi (colour) or markdown (one table)."`
+	Width      int    `short:"w" default:"120" help:"Width in columns of the rendered board."`
+	Filter     string `help:"Only show matching items, e.g. 'assignee:alice -status:Done'."`
+	GroupBy    string `name:"group-by" short:"g" help:"SingleSelect field to group columns by. Defaults to Status."`
+	Theme      string `help:"Colour theme for --format ansi: auto, dark, light, high-contrast, mono or a theme from the config file. auto follows NO_COLOR and the terminal background."`
+	ConfigFile string `name:"config" placeholder:"FILE" help:"Config file with user themes. Defaults to ~/.config/gh-kanban/config.yml."`
+}
+
+func (c *PrintCmd) Run() error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	filter, err := board.ParseFilter(c.Filter)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(c.ConfigFile)
+	if err != nil {
+		return err
+	}
+	theme, err := resolveTheme(cfg, c.Theme)
+	if err != nil {
+		return err
+	}
+
+	client, err := c.newClient()
+	if err != nil {
+		return err
+	}
+	spec, err := resolveSpecNonInteractive(client, c.spec())
+	if err != nil {
+		return err
+	}
+	// Cards show no bodies; field values are only needed to group or filter.
+	sel := gh.BoardItemSelection()
+	sel.AllFields = c.GroupBy != "" || filter.UsesFields()
+	client.SetItemSelection(sel)
+	project, err := client.LoadProject(spec)
+	if err != nil {
+		return err
+	}
+
+	out, err := tui.RenderSnapshot(project, tui.SnapshotOptions{
+		Width:   c.Width,
+		Format:  tui.SnapshotFormat(c.Format),
+		GroupBy: c.GroupBy,
+		Filter:  filter,
+		Theme:   theme,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Print(out)
+	return nil
+}
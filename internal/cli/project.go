@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// ProjectFlags selects the owner and project. Shared by every subcommand via
+// kong's embed so the flags stay identical across view/export/....
+type ProjectFlags struct {
+	User    string `short:"u" help:"GitHub user login that owns the project. Mutually exclusive with --org."`
+	Org     string `short:"o" help:"GitHub organization login that owns the project. Mutually exclusive with --user."`
+	Owner   string `help:"User or organization login that owns the project; which one it is is looked up. Mutually exclusive with --user and --org."`
+	Project string `short:"p" help:"Project title (exact match). Provide either --project or --number."`
+	Number  int    `short:"N" help:"Project number. Faster than --project because it skips the search query."`
+	Record  string `placeholder:"DIR" help:"Save every GitHub API request and response to DIR, for bug reports and regression tests."`
+	Replay  string `placeholder:"DIR" help:"Serve API responses from a --record directory instead of the network. The owner defaults to the recorded one."`
+
+	// Host is the GitHub host taken from a project URL; "" follows gh's
+	// configuration.
+	Host string `kong:"-"`
+}
+
+func (f ProjectFlags) validate() error {
+	if f.Project != "" && f.Number != 0 {
+		return errors.New("--project and --number are mutually exclusive")
+	}
+	if f.Record != "" && f.Replay != "" {
+		return errors.New("--record and --replay are mutually exclusive")
+	}
+	if f.Owner != "" && (f.User != "" || f.Org != "") {
+		return errors.New("--owner is mutually exclusive with --user/-u and --org/-o")
+	}
+	if f.Owner != "" && f.Replay != "" {
+		// Looking up the owner type needs the network.
+		return errors.New("--owner cannot be combined with --replay; use --user/-u or --org/-o")
+	}
+	return nil
+}
+
+// newClient builds a client for the owner given by -u/-o/--owner, falling back
+// to the owner of the repository in the current directory (or, with
+// --replay, the recorded owner).
+func (f ProjectFlags) newClient() (*gh.Client, error) {
+	client, _, err := f.connect(false)
+	return client, err
+}
+
+// connect is newClient that, with withLinked, also lists the projects linked
+// to the current repository when the owner was detected from it. detected is
+// the zero value otherwise.
+func (f ProjectFlags) connect(withLinked bool) (client *gh.Client, detected gh.DetectedRepo, err error) {
+	params := gh.InitParams{
+		UserLogin: f.User,
+		OrgLogin:  f.Org,
+	}
+	switch {
+	case f.Owner != "":
+		resolved, err := gh.ResolveOwner(f.Record, f.Owner)
+		if err != nil {
+			return nil, detected, fmt.Errorf("--owner: %w", err)
+		}
+		params = resolved
+	case params.UserLogin == "" && params.OrgLogin == "" && f.Replay == "":
+		if detected, err = gh.DetectOwnerFromCurrentRepo(f.Record, withLinked); err != nil {
+			return nil, detected, fmt.Errorf("could not auto-detect owner from current repository (%w); specify --user/-u, --org/-o or --owner", err)
+		}
+		params = detected.InitParams
+	}
+	client, err = f.clientFor(params)
+	return client, detected, err
+}
+
+// clientFor builds a client for the owner in params, recording or replaying
+// like the main one.
+func (f ProjectFlags) clientFor(params gh.InitParams) (*gh.Client, error) {
+	params.Record, params.Replay = f.Record, f.Replay
+	if params.Host == "" {
+		params.Host = f.Host
+	}
+	client, err := gh.NewClient(params)
+	if err != nil {
+		if errors.Is(err, gh.ErrInvalidClientType) {
+			return nil, errors.New("specify exactly one of --user/-u or --org/-o")
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+func (f ProjectFlags) spec() gh.ProjectSpec {
+	return gh.ProjectSpec{
+		Title:  f.Project,
+		Number: f.Number,
+	}
+}
+
+// resolveSpecNonInteractive fills in the project when neither -p nor -N was
+// given and the owner has exactly one project. Scripts must not hang on a
+// picker, so several candidates are an error listing them.
+func resolveSpecNonInteractive(client *gh.Client, spec gh.ProjectSpec) (gh.ProjectSpec, error) {
+	if spec.Title != "" || spec.Number != 0 {
+		return spec, nil
+	}
+	projects, err := client.ListProjects()
+	if err != nil {
+		return spec, fmt.Errorf("list projects: %w", err)
+	}
+	switch len(projects) {
+	case 0:
+		return spec, fmt.Errorf("no projects found for %s", client.Login)
+	case 1:
+		spec.Number = projects[0].Number
+		return spec, nil
+	default:
+		names := make([]string, 0, len(projects))
+		for _, p := range projects {
+			names = append(names, fmt.Sprintf("#%d %s", p.Number, p.Title))
+		}
+		return spec, fmt.Errorf("%s has %d projects, pick one with --number/-N: %s", client.Login, len(projects), strings.Join(names, ", "))
+	}
+}
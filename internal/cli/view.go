@@ -3,76 +3,390 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
+	"slices"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/cache"
+	"github.com/shuntaka9576/kanban/internal/config"
 	"github.com/shuntaka9576/kanban/internal/gh"
 	"github.com/shuntaka9576/kanban/internal/tui"
 )
 
 type ViewCmd struct {
-	User    string `short:"u" help:"GitHub user login that owns the project. Mutually exclusive with --org."`
-	Org     string `short:"o" help:"GitHub organization login that owns the project. Mutually exclusive with --user."`
-	Project string `short:"p" help:"Project title (exact match). Provide either --project or --number."`
-	Number  int    `short:"N" help:"Project number. Faster than --project because it skips the search query."`
+	ProjectFlags `embed:""`
+
+	Board string `arg:"" optional:"" help:"Board alias from the config file, or a project URL such as https://github.com/orgs/acme/projects/7."`
+
+	GroupBy    string         `name:"group-by" short:"g" help:"SingleSelect field to group columns by. Defaults to Status; n/b then set this field."`
+	Filter     string         `help:"Only show matching items, e.g. 'assignee:alice -label:wontfix'."`
+	Sort       string         `help:"Order cards within columns: number, title, updated, repo or a field name; prefix - for descending."`
+	Hide       []string       `placeholder:"COLUMN" help:"Column names to leave off the board (repeatable or comma-separated)."`
+	ConfigFile string         `name:"config" placeholder:"FILE" help:"Config file. Defaults to ~/.config/gh-kanban/config.yml."`
+	WIPLimits  map[string]int `name:"wip-limit" placeholder:"COLUMN=N" help:"WIP limit for a column (repeatable). Limits also come from option descriptions like \"WIP 3\"."`
+	ConfirmWIP *bool          `name:"confirm-wip" negatable:"" help:"Ask before a move takes a column over its WIP limit. --no-confirm-wip overrides confirm-wip in the config."`
+	Theme      string         `help:"Colour theme: auto, dark, light, high-contrast, mono or a theme from the config file. auto follows the terminal background and NO_COLOR."`
+
+	YankMaxComments  int           `name:"yank-max-comments" help:"Cap the comments (and review comments) copied by yank. 0 copies all of them."`
+	YankMaxDiffBytes int           `name:"yank-max-diff" help:"Cap in bytes for the PR diff copied by the extended yank (Y). 0 uses the 64 KiB default."`
+	YankBudget       string        `name:"yank-budget" help:"Size budget for yanked Markdown, in tokens (8000, 8k, 8k tokens) or bytes (32kb, 32768b). Older, less-reacted comments are dropped first."`
+	Offline          bool          `help:"Browse the last cached snapshot without any network access. Requires --user/--org and --number/--project."`
+	NoCache          bool          `name:"no-cache" help:"Do not read or write the on-disk board cache."`
+	Watch            time.Duration `help:"Start in live mode, re-syncing the board in the background every interval (e.g. 30s, 2m). Toggle with w."`
+	NoMouse          bool          `name:"no-mouse" help:"Leave the mouse to the terminal (e.g. for selecting text) instead of clicking and dragging cards."`
 }
 
 func (c *ViewCmd) Run() error {
-	if c.Project != "" && c.Number != 0 {
-		return errors.New("--project and --number are mutually exclusive")
+	if gh.LooksLikeProjectURL(c.Board) {
+		if err := c.applyURL(); err != nil {
+			return err
+		}
+	}
+	cfg, err := c.applyConfig()
+	if err != nil {
+		return err
+	}
+	if err := c.validate(); err != nil {
+		return err
+	}
+	keymap, err := tui.NewKeymap(cfg.KeyOverrides())
+	if err != nil {
+		return fmt.Errorf("config keys: %w", err)
+	}
+	theme, err := c.theme(cfg)
+	if err != nil {
+		return err
+	}
+	layout, err := c.layout()
+	if err != nil {
+		return err
+	}
+	budget, err := tui.ParseYankBudget(c.YankBudget)
+	if err != nil {
+		return err
+	}
+	if c.Watch != 0 && c.Watch < tui.MinWatchInterval {
+		return fmt.Errorf("--watch must be at least %s", tui.MinWatchInterval)
 	}
 
-	params := gh.InitParams{
-		UserLogin: c.User,
-		OrgLogin:  c.Org,
+	opts := tui.Options{
+		YankMaxComments:  c.YankMaxComments,
+		YankMaxDiffBytes: c.YankMaxDiffBytes,
+		YankBudget:       budget,
+		WatchInterval:    c.Watch,
+		Layout:           layout,
+		Keymap:           keymap,
+		Theme:            theme,
 	}
-	if params.UserLogin == "" && params.OrgLogin == "" {
-		detected, err := gh.DetectOwnerFromCurrentRepo()
-		if err != nil {
-			return fmt.Errorf("could not auto-detect owner from current repository (%w); specify --user/-u or --org/-o", err)
+	if c.Offline {
+		if c.Record != "" || c.Replay != "" {
+			return errors.New("--offline cannot be combined with --record or --replay")
 		}
-		params = detected
+		return c.runOffline(opts)
 	}
 
-	client, err := gh.NewClient(params)
+	spec := c.spec()
+	picking := spec.Title == "" && spec.Number == 0
+	client, detected, err := c.connect(picking)
 	if err != nil {
-		if errors.Is(err, gh.ErrInvalidClientType) {
-			return errors.New("specify exactly one of --user/-u or --org/-o")
-		}
 		return err
 	}
 
-	spec := gh.ProjectSpec{
-		Title:  c.Project,
-		Number: c.Number,
+	// The board shows titles, badges, assignees and labels; bodies are
+	// fetched only for the selected card.
+	sel := gh.BoardItemSelection(c.GroupBy)
+	sel.AllFields = layout.UsesFields()
+	client.SetItemSelection(sel)
+	opts.LazyBodies = true
+	clients := &ownerClients{flags: c.ProjectFlags, sel: sel, home: client}
+	// The switcher offers the same list as the picker at startup.
+	list := func() ([]gh.ProjectSummary, error) {
+		owned, err := clients.home.ListProjects()
+		if err != nil {
+			return nil, err
+		}
+		return mergeProjects(detected.Linked, owned), nil
 	}
 
-	if spec.Title == "" && spec.Number == 0 {
-		projects, err := client.ListProjects()
+	if picking {
+		projects, err := list()
 		if err != nil {
 			return fmt.Errorf("list projects: %w", err)
 		}
+		note := linkedNote(detected.LinkedErr)
+		var picked *gh.ProjectSummary
 		switch len(projects) {
 		case 0:
 			fmt.Printf("No projects found for %s.\n", client.Login)
+			if note != "" {
+				fmt.Println(note)
+			}
 			return nil
 		case 1:
-			spec.Number = projects[0].Number
+			picked = &projects[0]
+			if note != "" {
+				fmt.Fprintln(os.Stderr, note)
+			}
 		default:
-			sel, err := pickProject(projects)
-			if err != nil {
+			if picked, err = tui.PickProject(projects, note); err != nil {
 				return err
 			}
-			if sel == nil {
+			if picked == nil {
 				return nil
 			}
-			spec.Number = sel.Number
+		}
+		spec.Number = picked.Number
+		if client, err = clients.forProject(*picked); err != nil {
+			return err
+		}
+	}
+
+	// Column layouts are remembered even when the board cache is off. A
+	// replay shows exactly the recorded board, never a cached one.
+	store, _ := cache.Open()
+	opts.Layouts = store
+	useCache := store != nil && !c.NoCache && c.Replay == ""
+	// Projects opened with the switcher share the flags; each gets its own
+	// cache.
+	optsFor := func(client *gh.Client, spec gh.ProjectSpec) tui.Options {
+		o := opts
+		o.Owner = client.Login
+		if useCache {
+			o.Cache = store.For(client.ClientType, client.Login, spec)
+		}
+		return o
+	}
+	first := tui.New(client, spec, specLabel(spec), optsFor(client, spec))
+	return c.runTUI(tui.NewSession(first, tui.Switcher{
+		List: list,
+		Open: func(ps gh.ProjectSummary) (tui.Backend, tui.Options, error) {
+			client, err := clients.forProject(ps)
+			if err != nil {
+				return nil, tui.Options{}, err
+			}
+			return client, optsFor(client, gh.ProjectSpec{Number: ps.Number}), nil
+		},
+	}))
+}
+
+// ownerClients hands out one client per project owner, so boards of the
+// same owner share theirs.
+type ownerClients struct {
+	flags   ProjectFlags
+	sel     gh.ItemSelection
+	home    *gh.Client // the owner from the flags or the repository
+	byOwner map[string]*gh.Client
+}
+
+// forProject returns a client of ps's owner. A project linked to the
+// repository may belong to someone other than the home owner.
+func (o *ownerClients) forProject(ps gh.ProjectSummary) (*gh.Client, error) {
+	if ps.OwnerLogin == "" || (ps.OwnerLogin == o.home.Login && ps.OwnerType == o.home.ClientType) {
+		return o.home, nil
+	}
+	key := string(ps.OwnerType) + "/" + ps.OwnerLogin
+	if client, ok := o.byOwner[key]; ok {
+		return client, nil
+	}
+	client, err := o.flags.clientFor(gh.OwnerParams(ps.OwnerType, ps.OwnerLogin))
+	if err != nil {
+		return nil, err
+	}
+	client.SetItemSelection(o.sel)
+	if o.byOwner == nil {
+		o.byOwner = map[string]*gh.Client{}
+	}
+	o.byOwner[key] = client
+	return client, nil
+}
+
+// runOffline shows the cached board. Without the network there is no owner
+// detection and no project list, so both have to be given explicitly.
+func (c *ViewCmd) runOffline(opts tui.Options) error {
+	if c.NoCache {
+		return errors.New("--offline reads the cache and cannot be combined with --no-cache")
+	}
+	ownerType, login := gh.ClientTypeUser, c.User
+	switch {
+	case c.User != "" && c.Org != "":
+		return errors.New("specify exactly one of --user/-u or --org/-o")
+	case c.Owner != "":
+		return errors.New("--offline cannot look up whether --owner is a user or an organization; specify --user/-u or --org/-o")
+	case c.Org != "":
+		ownerType, login = gh.ClientTypeOrganization, c.Org
+	case c.User == "":
+		return errors.New("--offline needs the owner: specify --user/-u or --org/-o")
+	}
+	spec := c.spec()
+	if spec.Title == "" && spec.Number == 0 {
+		return errors.New("--offline needs the project: specify --number/-N or --project/-p")
+	}
+
+	store, err := cache.Open()
+	if err != nil {
+		return err
+	}
+	opts.Cache = store.For(ownerType, login, spec)
+	opts.Layouts = store
+	opts.Offline = true
+	opts.Owner = login
+	first := tui.New(nil, spec, specLabel(spec), opts)
+	return c.runTUI(tui.NewSession(first, tui.Switcher{}))
+}
+
+// applyURL takes the host, owner and project number from a project URL given
+// in place of a board alias.
+func (c *ViewCmd) applyURL() error {
+	ref, err := gh.ParseProjectURL(c.Board)
+	if err != nil {
+		return err
+	}
+	if c.User != "" || c.Org != "" || c.Owner != "" || c.Project != "" || c.Number != 0 {
+		return errors.New("a project URL already names the owner and project; drop --user, --org, --owner, --project and --number")
+	}
+	c.Board = ""
+	params := ref.Params()
+	c.User, c.Org, c.Number, c.Host = params.UserLogin, params.OrgLogin, ref.Number, ref.Host
+	return nil
+}
+
+// linkedNote explains why the projects linked to the current repository are
+// missing from the list, or is "" when they are not.
+func linkedNote(err error) string {
+	if err == nil {
+		return ""
+	}
+	return fmt.Sprintf("Projects linked to this repository are not listed: %v", err)
+}
+
+// mergeProjects lists the projects linked to the current repository first,
+// then the owner's, without repeating those in both.
+func mergeProjects(linked, owned []gh.ProjectSummary) []gh.ProjectSummary {
+	out := slices.Clip(linked)
+	for _, p := range owned {
+		if !slices.ContainsFunc(linked, func(l gh.ProjectSummary) bool { return l.ID == p.ID }) {
+			out = append(out, p)
 		}
 	}
+	return out
+}
 
-	label := specLabel(spec)
+// applyConfig fills in whatever the flags leave unset from the config file:
+// the named board alias over the defaults. The owner is taken when none of
+// -u, -o and --owner is given, and the project too unless the owner came from a flag
+// without an alias. It returns the loaded config for the key and theme
+// settings.
+func (c *ViewCmd) applyConfig() (*config.Config, error) {
+	cfg, err := loadConfig(c.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	b, err := cfg.Board(c.Board)
+	if err != nil {
+		return nil, err
+	}
 
-	model := tui.New(client, spec, label)
-	program := tea.NewProgram(model, tea.WithAltScreen())
+	ownerFromFlags := c.User != "" || c.Org != "" || c.Owner != ""
+	if !ownerFromFlags {
+		c.User, c.Org = b.User, b.Org
+	}
+	if c.Project == "" && c.Number == 0 && (!ownerFromFlags || c.Board != "") {
+		c.Project, c.Number = b.Project, b.Number
+	}
+	if c.GroupBy == "" {
+		c.GroupBy = b.GroupBy
+	}
+	if c.Filter == "" {
+		c.Filter = b.Filter
+	}
+	if c.Sort == "" {
+		c.Sort = b.Sort
+	}
+	if c.Hide == nil {
+		c.Hide = b.Hide
+	}
+	if c.Theme == "" {
+		c.Theme = b.Theme
+	}
+	if c.WIPLimits == nil {
+		c.WIPLimits = b.WIPLimits
+	}
+	if c.ConfirmWIP == nil {
+		c.ConfirmWIP = b.ConfirmWIP
+	}
+	return cfg, nil
+}
+
+// loadConfig reads the config file at path, or at the default location when
+// path is "".
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		var err error
+		if path, err = config.Path(); err != nil {
+			return nil, err
+		}
+	}
+	return config.Load(path)
+}
+
+// theme resolves --theme.
+func (c *ViewCmd) theme(cfg *config.Config) (tui.Theme, error) {
+	return resolveTheme(cfg, c.Theme)
+}
+
+// resolveTheme finds the theme called name: a user theme from the config
+// file over the built-in one it extends, or a built-in theme.
+func resolveTheme(cfg *config.Config, name string) (tui.Theme, error) {
+	user, ok := cfg.Themes[name]
+	if !ok || name == "" {
+		return tui.ThemeByName(name)
+	}
+	base, err := tui.ThemeByName(user.Extends)
+	if err != nil {
+		return tui.Theme{}, fmt.Errorf("theme %s: %w", name, err)
+	}
+	return base.With(tui.Theme{
+		Name:     name,
+		Border:   user.Border,
+		Focus:    user.Focus,
+		Selected: user.Selected,
+		Moving:   user.Moving,
+		Changed:  user.Changed,
+		Muted:    user.Muted,
+		Error:    user.Error,
+		Options:  user.Options,
+	}), nil
+}
+
+func (c *ViewCmd) layout() (tui.BoardLayout, error) {
+	filter, err := board.ParseFilter(c.Filter)
+	if err != nil {
+		return tui.BoardLayout{}, err
+	}
+	order, err := board.ParseSort(c.Sort)
+	if err != nil {
+		return tui.BoardLayout{}, err
+	}
+	return tui.BoardLayout{
+		GroupBy:       c.GroupBy,
+		Filter:        filter,
+		Sort:          order,
+		Hidden:        c.Hide,
+		Limits:        c.WIPLimits,
+		ConfirmLimits: c.ConfirmWIP != nil && *c.ConfirmWIP,
+	}, nil
+}
+
+func (c *ViewCmd) runTUI(model tea.Model) error {
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if !c.NoMouse {
+		// Cell motion reports movement only while a button is held, which
+		// is all dragging needs.
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	program := tea.NewProgram(model, opts...)
 	if _, err := program.Run(); err != nil {
 		return err
 	}
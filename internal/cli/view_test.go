@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/tui"
+)
+
+const testConfig = `
+defaults:
+  org: acme
+  number: 1
+  group-by: Priority
+  hide: [Done]
+boards:
+  sprint:
+    number: 7
+    sort: -updated
+keys:
+  yank: c
+`
+
+func TestViewCmd_ApplyConfig(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		cmd  ViewCmd
+		want ViewCmd
+	}{
+		{
+			name: "defaults",
+			cmd:  ViewCmd{},
+			want: ViewCmd{ProjectFlags: ProjectFlags{Org: "acme", Number: 1}, GroupBy: "Priority", Hide: []string{"Done"}},
+		},
+		{
+			name: "alias",
+			cmd:  ViewCmd{Board: "sprint"},
+			want: ViewCmd{ProjectFlags: ProjectFlags{Org: "acme", Number: 7}, Board: "sprint", GroupBy: "Priority", Sort: "-updated", Hide: []string{"Done"}},
+		},
+		{
+			name: "flags win",
+			cmd:  ViewCmd{Board: "sprint", ProjectFlags: ProjectFlags{Project: "Roadmap"}, GroupBy: "Status", Hide: []string{}},
+			want: ViewCmd{ProjectFlags: ProjectFlags{Org: "acme", Project: "Roadmap"}, Board: "sprint", GroupBy: "Status", Sort: "-updated", Hide: []string{}},
+		},
+		{
+			name: "--owner keeps the configured owner away",
+			cmd:  ViewCmd{ProjectFlags: ProjectFlags{Owner: "bob"}},
+			want: ViewCmd{ProjectFlags: ProjectFlags{Owner: "bob"}, GroupBy: "Priority", Hide: []string{"Done"}},
+		},
+		{
+			name: "owner flag without alias keeps the configured project away",
+			cmd:  ViewCmd{ProjectFlags: ProjectFlags{User: "bob"}},
+			want: ViewCmd{ProjectFlags: ProjectFlags{User: "bob"}, GroupBy: "Priority", Hide: []string{"Done"}},
+		},
+	}
+	for _, tc := range cases {
+		cmd := tc.cmd
+		cmd.ConfigFile = path
+		cfg, err := cmd.applyConfig()
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		keys := cfg.KeyOverrides()
+		if len(keys["yank"]) != 1 || keys["yank"][0] != "c" {
+			t.Errorf("%s: keys = %v", tc.name, keys)
+		}
+		cmd.ConfigFile = ""
+		if diff := cmp.Diff(tc.want, cmd); diff != "" {
+			t.Errorf("%s (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestViewCmd_Theme(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	body := "themes:\n  mine:\n    extends: light\n    focus: \"#268bd2\"\n  broken:\n    extends: sepia\n"
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := ViewCmd{ConfigFile: path, Theme: "mine"}
+	cfg, err := cmd.applyConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	theme, err := cmd.theme(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	light, _ := tui.ThemeByName("light")
+	if theme.Name != "mine" || theme.Focus != "#268bd2" || theme.Border != light.Border {
+		t.Errorf("theme = %+v, want light with the focus colour replaced", theme)
+	}
+
+	cmd.Theme = "broken"
+	if _, err := cmd.theme(cfg); err == nil {
+		t.Error("a theme extending an unknown one should fail")
+	}
+	cmd.Theme = "high-contrast"
+	if theme, err := cmd.theme(cfg); err != nil || theme.Name != "high-contrast" {
+		t.Errorf("built-in theme = %+v, %v", theme, err)
+	}
+}
+
+func TestViewCmd_ApplyURL(t *testing.T) {
+	t.Parallel()
+	cmd := ViewCmd{Board: "https://github.com/users/bob/projects/3/views/1"}
+	if err := cmd.applyURL(); err != nil {
+		t.Fatal(err)
+	}
+	want := ViewCmd{ProjectFlags: ProjectFlags{User: "bob", Number: 3, Host: "github.com"}}
+	if diff := cmp.Diff(want, cmd); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	cmd = ViewCmd{Board: "https://github.com/orgs/acme/projects/7", ProjectFlags: ProjectFlags{Number: 2}}
+	if err := cmd.applyURL(); err == nil {
+		t.Error("a URL with --number: want an error")
+	}
+	cmd = ViewCmd{Board: "https://github.com/acme/app/projects/1"}
+	if err := cmd.applyURL(); err == nil {
+		t.Error("a classic project URL: want an error")
+	}
+}
+
+func TestMergeProjects(t *testing.T) {
+	t.Parallel()
+	linked := []gh.ProjectSummary{
+		{ID: "P_acme", Number: 7, Title: "Platform", OwnerType: gh.ClientTypeOrganization, OwnerLogin: "acme"},
+		{ID: "P_1", Number: 1, Title: "Backlog", OwnerType: gh.ClientTypeUser, OwnerLogin: "alice"},
+	}
+	owned := []gh.ProjectSummary{
+		{ID: "P_1", Number: 1, Title: "Backlog", OwnerType: gh.ClientTypeUser, OwnerLogin: "alice"},
+		{ID: "P_2", Number: 2, Title: "Ideas", OwnerType: gh.ClientTypeUser, OwnerLogin: "alice"},
+	}
+	got := mergeProjects(linked, owned)
+	want := []gh.ProjectSummary{linked[0], linked[1], owned[1]}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,194 @@
+// Package config reads the optional user configuration file: default
+// owner/project and board layout, named board aliases, and key overrides.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Board holds the settings one board alias (or the defaults) can set. Empty
+// fields mean "not set".
+type Board struct {
+	User    string   `yaml:"user"`
+	Org     string   `yaml:"org"`
+	Project string   `yaml:"project"`
+	Number  int      `yaml:"number"`
+	GroupBy string   `yaml:"group-by"`
+	Filter  string   `yaml:"filter"`
+	Sort    string   `yaml:"sort"`
+	Hide    []string `yaml:"hide"`
+	Theme   string   `yaml:"theme"`
+	// WIPLimits caps cards per column, column name to limit.
+	WIPLimits map[string]int `yaml:"wip-limits"`
+	// ConfirmWIP is nil when unset, so a board can turn off the default.
+	ConfirmWIP *bool `yaml:"confirm-wip"`
+}
+
+// Config is the whole file:
+//
+//	defaults:
+//	  org: acme
+//	  hide: [Done]
+//	boards:
+//	  sprint:
+//	    number: 7
+//	    filter: "-label:wontfix"
+//	    wip-limits: {In Progress: 3}
+//	keys:
+//	  yank: c
+//	  column-left: [h, left, ctrl+b]
+//	themes:
+//	  solarized:
+//	    extends: light
+//	    focus: "#268bd2"
+//	    options: {GREEN: "#859900"}
+type Config struct {
+	Defaults Board            `yaml:"defaults"`
+	Boards   map[string]Board `yaml:"boards"`
+	// Keys rebinds TUI actions, action name to its keys.
+	Keys map[string]KeyList `yaml:"keys"`
+	// Themes are user themes, selectable by name like the built-in ones.
+	Themes map[string]Theme `yaml:"themes"`
+}
+
+// Theme is a user theme: a built-in one (Extends; auto when empty) with
+// some colours replaced. Colours are ANSI 256 codes or #rrggbb.
+type Theme struct {
+	Extends  string            `yaml:"extends"`
+	Border   string            `yaml:"border"`
+	Focus    string            `yaml:"focus"`
+	Selected string            `yaml:"selected"`
+	Moving   string            `yaml:"moving"`
+	Changed  string            `yaml:"changed"`
+	Muted    string            `yaml:"muted"`
+	Error    string            `yaml:"error"`
+	Options  map[string]string `yaml:"options"` // GitHub option colour (GREEN) to colour
+}
+
+// KeyList is one key or a list of them; [] unbinds the action.
+type KeyList []string
+
+func (k *KeyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = KeyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	if *k == nil {
+		*k = KeyList{}
+	}
+	return nil
+}
+
+// KeyOverrides returns Keys as plain string lists.
+func (c *Config) KeyOverrides() map[string][]string {
+	if len(c.Keys) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(c.Keys))
+	for action, keys := range c.Keys {
+		out[action] = keys
+	}
+	return out
+}
+
+// Path is where the config file lives: $GH_KANBAN_CONFIG, else
+// $XDG_CONFIG_HOME/gh-kanban/config.yml, else ~/.config/gh-kanban/config.yml.
+func Path() (string, error) {
+	if p := os.Getenv("GH_KANBAN_CONFIG"); p != "" {
+		return p, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh-kanban", "config.yml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("config: %w", err)
+	}
+	return filepath.Join(home, ".config", "gh-kanban", "config.yml"), nil
+}
+
+// Load reads the config file at path. A missing file is an empty config;
+// unknown keys are errors so typos don't go unnoticed.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	var c Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Board returns the defaults overlaid with the named alias. An empty alias
+// returns the defaults alone.
+func (c *Config) Board(alias string) (Board, error) {
+	b := c.Defaults
+	if alias == "" {
+		return b, nil
+	}
+	a, ok := c.Boards[alias]
+	if !ok {
+		return Board{}, fmt.Errorf("no board %q in the config file%s", alias, c.aliasHint())
+	}
+	if a.User != "" || a.Org != "" {
+		b.User, b.Org = a.User, a.Org
+	}
+	if a.Project != "" || a.Number != 0 {
+		b.Project, b.Number = a.Project, a.Number
+	}
+	if a.GroupBy != "" {
+		b.GroupBy = a.GroupBy
+	}
+	if a.Filter != "" {
+		b.Filter = a.Filter
+	}
+	if a.Sort != "" {
+		b.Sort = a.Sort
+	}
+	if a.Hide != nil {
+		b.Hide = a.Hide
+	}
+	if a.Theme != "" {
+		b.Theme = a.Theme
+	}
+	if a.WIPLimits != nil {
+		b.WIPLimits = a.WIPLimits
+	}
+	if a.ConfirmWIP != nil {
+		b.ConfirmWIP = a.ConfirmWIP
+	}
+	return b, nil
+}
+
+func (c *Config) aliasHint() string {
+	if len(c.Boards) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(c.Boards))
+	for name := range c.Boards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return " (known: " + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_BoardOverlaysDefaults(t *testing.T) {
+	t.Parallel()
+	cfg, err := Load(writeConfig(t, `
+defaults:
+  org: acme
+  number: 1
+  sort: -updated
+  hide: [Done]
+boards:
+  sprint:
+    number: 7
+    filter: "-label:wontfix"
+  mine:
+    user: alice
+    project: Personal
+    hide: []
+keys:
+  yank: c
+  column-left: [h, ctrl+b]
+  live: []
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]Board{
+		"":       {Org: "acme", Number: 1, Sort: "-updated", Hide: []string{"Done"}},
+		"sprint": {Org: "acme", Number: 7, Sort: "-updated", Filter: "-label:wontfix", Hide: []string{"Done"}},
+		"mine":   {User: "alice", Project: "Personal", Sort: "-updated", Hide: []string{}},
+	}
+	for alias, want := range cases {
+		got, err := cfg.Board(alias)
+		if err != nil {
+			t.Fatalf("Board(%q): %v", alias, err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Board(%q) (-want +got):\n%s", alias, diff)
+		}
+	}
+	wantKeys := map[string][]string{"yank": {"c"}, "column-left": {"h", "ctrl+b"}, "live": {}}
+	if diff := cmp.Diff(wantKeys, cfg.KeyOverrides()); diff != "" {
+		t.Errorf("keys (-want +got):\n%s", diff)
+	}
+
+	if _, err := cfg.Board("nope"); err == nil || !strings.Contains(err.Error(), "known: mine, sprint") {
+		t.Errorf("unknown alias: err = %v", err)
+	}
+}
+
+func TestLoad_MissingFileIsEmpty(t *testing.T) {
+	t.Parallel()
+	cfg, err := Load(filepath.Join(t.TempDir(), "absent.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := cfg.Board(""); !cmp.Equal(b, Board{}) {
+		t.Fatalf("defaults = %+v, want empty", b)
+	}
+}
+
+func TestLoad_RejectsUnknownKeys(t *testing.T) {
+	t.Parallel()
+	_, err := Load(writeConfig(t, "defaults:\n  orgg: acme\n"))
+	if err == nil || !strings.Contains(err.Error(), "orgg") {
+		t.Fatalf("err = %v, want it to name the unknown key", err)
+	}
+}
+
+func TestLoad_Themes(t *testing.T) {
+	t.Parallel()
+	cfg, err := Load(writeConfig(t, `
+defaults:
+  theme: solarized
+boards:
+  demo:
+    theme: high-contrast
+themes:
+  solarized:
+    extends: light
+    focus: "#268bd2"
+    options:
+      GREEN: "#859900"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Theme{Extends: "light", Focus: "#268bd2", Options: map[string]string{"GREEN": "#859900"}}
+	if diff := cmp.Diff(want, cfg.Themes["solarized"]); diff != "" {
+		t.Errorf("theme (-want +got):\n%s", diff)
+	}
+	if b, _ := cfg.Board("demo"); b.Theme != "high-contrast" {
+		t.Errorf("alias theme = %q, want high-contrast", b.Theme)
+	}
+}
+
+func TestLoad_WIPLimits(t *testing.T) {
+	t.Parallel()
+	cfg, err := Load(writeConfig(t, `
+defaults:
+  wip-limits: {In Progress: 3}
+  confirm-wip: true
+boards:
+  review:
+    wip-limits: {Review: 2}
+  quiet:
+    confirm-wip: false
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	yes, no := true, false
+	def, _ := cfg.Board("")
+	if diff := cmp.Diff(Board{WIPLimits: map[string]int{"In Progress": 3}, ConfirmWIP: &yes}, def); diff != "" {
+		t.Errorf("defaults (-want +got):\n%s", diff)
+	}
+	review, _ := cfg.Board("review")
+	if diff := cmp.Diff(Board{WIPLimits: map[string]int{"Review": 2}, ConfirmWIP: &yes}, review); diff != "" {
+		t.Errorf("alias (-want +got):\n%s", diff)
+	}
+	quiet, _ := cfg.Board("quiet")
+	if diff := cmp.Diff(Board{WIPLimits: map[string]int{"In Progress": 3}, ConfirmWIP: &no}, quiet); diff != "" {
+		t.Errorf("alias turning confirm-wip off (-want +got):\n%s", diff)
+	}
+}
@@ -3,6 +3,9 @@ package gh
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 )
@@ -18,16 +21,56 @@ var ErrInvalidClientType = errors.New("specify exactly one of --user or --org")
 
 type Client struct {
 	gql        *api.GraphQLClient
+	opts       api.ClientOptions // what gql was built from; REST calls reuse it
 	ClientType ClientType
 	Login      string
+
+	mu        sync.Mutex
+	rateLimit RateLimit
+	limitHdr  http.Header         // rate-limit headers of the latest response
+	sleep     func(time.Duration) // retry backoff; nil means time.Sleep
+	selection *ItemSelection      // nil means FullItemSelection
 }
 
 type InitParams struct {
 	UserLogin string
 	OrgLogin  string
+	// Host is the GitHub host to talk to; "" follows gh's configuration
+	// (GH_HOST, else github.com).
+	Host string
+	// Record saves every exchange, GraphQL and REST, to this directory.
+	Record string
+	// Replay answers every request from a directory written by Record,
+	// without network access or a token. The owner defaults to the
+	// recorded one.
+	Replay string
+}
+
+// OwnerParams returns the parameters for a client of the given owner.
+func OwnerParams(ownerType ClientType, login string) InitParams {
+	if ownerType == ClientTypeOrganization {
+		return InitParams{OrgLogin: login}
+	}
+	return InitParams{UserLogin: login}
 }
 
 func NewClient(p InitParams) (*Client, error) {
+	if p.Record != "" && p.Replay != "" {
+		return nil, errors.New("record and replay are mutually exclusive")
+	}
+	opts := api.ClientOptions{Timeout: requestTimeout, Host: p.Host}
+	if p.Replay != "" {
+		rp, err := newReplayer(p.Replay)
+		if err != nil {
+			return nil, err
+		}
+		if p.UserLogin == "" && p.OrgLogin == "" && len(rp.meta.Owners) > 0 {
+			owner := OwnerParams(rp.meta.Owners[0].Type, rp.meta.Owners[0].Login)
+			p.UserLogin, p.OrgLogin = owner.UserLogin, owner.OrgLogin
+		}
+		// Fixed host and token keep go-gh from looking up credentials.
+		opts.Host, opts.AuthToken, opts.Transport = "github.com", "replay", rp
+	}
 	if p.UserLogin != "" && p.OrgLogin != "" {
 		return nil, ErrInvalidClientType
 	}
@@ -35,12 +78,7 @@ func NewClient(p InitParams) (*Client, error) {
 		return nil, ErrInvalidClientType
 	}
 
-	gql, err := api.DefaultGraphQLClient()
-	if err != nil {
-		return nil, fmt.Errorf("graphql client: %w", err)
-	}
-
-	c := &Client{gql: gql}
+	c := &Client{}
 	if p.UserLogin != "" {
 		c.ClientType = ClientTypeUser
 		c.Login = p.UserLogin
@@ -48,9 +86,46 @@ func NewClient(p InitParams) (*Client, error) {
 		c.ClientType = ClientTypeOrganization
 		c.Login = p.OrgLogin
 	}
+
+	if err := c.dial(opts, p.Record); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
+// newLookupClient is a client without an owner, for the queries that find
+// one. It records into record like NewClient.
+func newLookupClient(record string) (*Client, error) {
+	c := &Client{}
+	if err := c.dial(api.ClientOptions{Timeout: requestTimeout}, record); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// dial sets up c's GraphQL client, saving every exchange to record when set.
+func (c *Client) dial(opts api.ClientOptions, record string) error {
+	next := opts.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if record != "" {
+		rec, err := newRecorder(record, c.ClientType, c.Login, next)
+		if err != nil {
+			return err
+		}
+		next = rec
+	}
+	opts.Transport = headerWatcher{c: c, next: next}
+	gql, err := api.NewGraphQLClient(opts)
+	if err != nil {
+		return fmt.Errorf("graphql client: %w", err)
+	}
+	c.gql = gql
+	c.opts = opts
+	return nil
+}
+
 func (c *Client) ownerSelector() string {
 	if c.ClientType == ClientTypeOrganization {
 		return "organization"
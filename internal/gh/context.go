@@ -9,6 +9,17 @@ type Comment struct {
 	Author    string
 	Body      string
 	CreatedAt time.Time
+	Reactions int
+}
+
+// ReviewThread is one inline review conversation on a pull request, anchored
+// to a file path and (for non-outdated threads) a line in the diff.
+type ReviewThread struct {
+	Path       string
+	Line       int
+	IsResolved bool
+	IsOutdated bool
+	Comments   []Comment
 }
 
 type ItemContext struct {
@@ -24,17 +35,55 @@ type ItemContext struct {
 	Assignees      []string
 	Labels         []string
 	Comments       []Comment
-	CommentsCapped bool
+	TotalComments  int  // comments.totalCount reported by GitHub
+	CommentsCapped bool // fewer than TotalComments were fetched because of MaxComments
+	ReviewThreads  []ReviewThread
+	// TotalReviewComments counts the inline review comments of every
+	// thread paged through, including those dropped by MaxComments. Once
+	// the cap is reached the remaining threads are not paged, so it
+	// misses theirs; TotalReviewThreads tells how many threads there are.
+	TotalReviewComments  int
+	ReviewCommentsCapped bool
+	TotalReviewThreads   int // reviewThreads.totalCount reported by GitHub
+
+	// Populated only when ItemContextOptions.Extended is set.
+	Extended      bool
+	Timeline      []TimelineEvent
+	Reviews       []Review      // PR only
+	Files         []ChangedFile // PR only
+	Diff          string        // PR only, unified diff capped at MaxDiffBytes
+	DiffTruncated bool
+	// DiffError says why Diff is empty when GitHub would not serve it, e.g.
+	// a 406 for a diff too large to generate. The other sections are kept.
+	DiffError string
 }
 
+// ItemContextOptions tunes how much of an item's discussion FetchItemContext
+// pulls in. The zero value fetches everything.
+type ItemContextOptions struct {
+	// MaxComments caps both the conversation comments and the inline review
+	// comments (counted separately). 0 means no cap.
+	MaxComments int
+	// Extended additionally loads key timeline events and, for pull
+	// requests, reviews, the changed-files list and the unified diff.
+	Extended bool
+	// MaxDiffBytes caps the diff loaded by Extended. 0 means
+	// DefaultMaxDiffBytes.
+	MaxDiffBytes int
+}
+
+// contextPageSize is the largest page GitHub allows for comment connections.
+const contextPageSize = 100
+
 const itemContextQuery = `
-query ItemContext($itemId: ID!) {
+query ItemContext($itemId: ID!, $first: Int!) {
   node(id: $itemId) {
     __typename
     ... on ProjectV2Item {
       content {
         __typename
         ... on Issue {
+          id
           number
           title
           body
@@ -45,17 +94,19 @@ query ItemContext($itemId: ID!) {
           repository { nameWithOwner }
           assignees(first: 20) { nodes { login } }
           labels(first: 20) { nodes { name } }
-          comments(first: 100) {
+          comments(first: $first) {
             totalCount
-            pageInfo { hasNextPage }
+            pageInfo { hasNextPage endCursor }
             nodes {
               author { login }
               body
               createdAt
+              reactions { totalCount }
             }
           }
         }
         ... on PullRequest {
+          id
           number
           title
           body
@@ -66,13 +117,14 @@ query ItemContext($itemId: ID!) {
           repository { nameWithOwner }
           assignees(first: 20) { nodes { login } }
           labels(first: 20) { nodes { name } }
-          comments(first: 100) {
+          comments(first: $first) {
             totalCount
-            pageInfo { hasNextPage }
+            pageInfo { hasNextPage endCursor }
             nodes {
               author { login }
               body
               createdAt
+              reactions { totalCount }
             }
           }
         }
@@ -89,48 +141,165 @@ query ItemContext($itemId: ID!) {
 }
 `
 
-func (c *Client) FetchItemContext(itemID string) (*ItemContext, error) {
-	type loginNode struct {
-		Login string `json:"login"`
-	}
-	type loginConn struct {
-		Nodes []loginNode `json:"nodes"`
+const commentsPageQuery = `
+query CommentsPage($id: ID!, $first: Int!, $cursor: String) {
+  node(id: $id) {
+    ... on Issue {
+      comments(first: $first, after: $cursor) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          author { login }
+          body
+          createdAt
+          reactions { totalCount }
+        }
+      }
+    }
+    ... on PullRequest {
+      comments(first: $first, after: $cursor) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          author { login }
+          body
+          createdAt
+          reactions { totalCount }
+        }
+      }
+    }
+  }
+}
+`
+
+const reviewThreadsPageQuery = `
+query ReviewThreadsPage($id: ID!, $first: Int!, $cursor: String) {
+  node(id: $id) {
+    ... on PullRequest {
+      reviewThreads(first: 50, after: $cursor) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          path
+          line
+          originalLine
+          isResolved
+          isOutdated
+          comments(first: $first) {
+            totalCount
+            pageInfo { hasNextPage endCursor }
+            nodes {
+              author { login }
+              body
+              createdAt
+              reactions { totalCount }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+const threadCommentsPageQuery = `
+query ThreadCommentsPage($id: ID!, $first: Int!, $cursor: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: $first, after: $cursor) {
+        totalCount
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          author { login }
+          body
+          createdAt
+          reactions { totalCount }
+        }
+      }
+    }
+  }
+}
+`
+
+type rawLogin struct {
+	Login string `json:"login"`
+}
+
+type rawComment struct {
+	Author    *rawLogin `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"createdAt"`
+	Reactions struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"reactions"`
+}
+
+type rawCommentsConn struct {
+	TotalCount int `json:"totalCount"`
+	PageInfo   struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+	Nodes []rawComment `json:"nodes"`
+}
+
+func (conn rawCommentsConn) comments() []Comment {
+	out := make([]Comment, 0, len(conn.Nodes))
+	for _, n := range conn.Nodes {
+		login := ""
+		if n.Author != nil {
+			login = n.Author.Login
+		}
+		out = append(out, Comment{
+			Author:    login,
+			Body:      n.Body,
+			CreatedAt: n.CreatedAt,
+			Reactions: n.Reactions.TotalCount,
+		})
 	}
+	return out
+}
+
+type rawReviewThread struct {
+	ID           string          `json:"id"`
+	Path         string          `json:"path"`
+	Line         int             `json:"line"`
+	OriginalLine int             `json:"originalLine"`
+	IsResolved   bool            `json:"isResolved"`
+	IsOutdated   bool            `json:"isOutdated"`
+	Comments     rawCommentsConn `json:"comments"`
+}
+
+// FetchItemContext loads an item's content together with its full discussion:
+// conversation comments and, for pull requests, inline review threads. Both
+// are paged past GitHub's 100-node limit until exhausted or opts.MaxComments
+// is reached.
+func (c *Client) FetchItemContext(itemID string, opts ItemContextOptions) (*ItemContext, error) {
 	type nameNode struct {
 		Name string `json:"name"`
 	}
-	type nameConn struct {
-		Nodes []nameNode `json:"nodes"`
-	}
-	type commentNode struct {
-		Author    *loginNode `json:"author"`
-		Body      string     `json:"body"`
-		CreatedAt time.Time  `json:"createdAt"`
-	}
-	type commentsConn struct {
-		TotalCount int `json:"totalCount"`
-		PageInfo   struct {
-			HasNextPage bool `json:"hasNextPage"`
-		} `json:"pageInfo"`
-		Nodes []commentNode `json:"nodes"`
-	}
-	type repoNode struct {
-		NameWithOwner string `json:"nameWithOwner"`
-	}
 	type contentNode struct {
-		Typename   string       `json:"__typename"`
-		Number     int          `json:"number"`
-		Title      string       `json:"title"`
-		Body       string       `json:"body"`
-		URL        string       `json:"url"`
-		State      string       `json:"state"`
-		CreatedAt  time.Time    `json:"createdAt"`
-		Author     *loginNode   `json:"author"`
-		Creator    *loginNode   `json:"creator"`
-		Repository *repoNode    `json:"repository"`
-		Assignees  loginConn    `json:"assignees"`
-		Labels     nameConn     `json:"labels"`
-		Comments   commentsConn `json:"comments"`
+		Typename   string    `json:"__typename"`
+		ID         string    `json:"id"`
+		Number     int       `json:"number"`
+		Title      string    `json:"title"`
+		Body       string    `json:"body"`
+		URL        string    `json:"url"`
+		State      string    `json:"state"`
+		CreatedAt  time.Time `json:"createdAt"`
+		Author     *rawLogin `json:"author"`
+		Creator    *rawLogin `json:"creator"`
+		Repository *struct {
+			NameWithOwner string `json:"nameWithOwner"`
+		} `json:"repository"`
+		Assignees struct {
+			Nodes []rawLogin `json:"nodes"`
+		} `json:"assignees"`
+		Labels struct {
+			Nodes []nameNode `json:"nodes"`
+		} `json:"labels"`
+		Comments rawCommentsConn `json:"comments"`
 	}
 	type itemNode struct {
 		Typename string       `json:"__typename"`
@@ -140,10 +309,13 @@ func (c *Client) FetchItemContext(itemID string) (*ItemContext, error) {
 		Node *itemNode `json:"node"`
 	}
 
-	variables := map[string]any{"itemId": itemID}
+	variables := map[string]any{
+		"itemId": itemID,
+		"first":  commentPageSize(opts.MaxComments, 0),
+	}
 
 	var resp response
-	if err := c.gql.Do(itemContextQuery, variables, &resp); err != nil {
+	if err := c.query(itemContextQuery, variables, &resp); err != nil {
 		return nil, fmt.Errorf("fetch item context: %w", err)
 	}
 	if resp.Node == nil || resp.Node.Content == nil {
@@ -175,17 +347,134 @@ func (c *Client) FetchItemContext(itemID string) (*ItemContext, error) {
 	for _, l := range ctn.Labels.Nodes {
 		ctx.Labels = append(ctx.Labels, l.Name)
 	}
-	for _, c := range ctn.Comments.Nodes {
-		login := ""
-		if c.Author != nil {
-			login = c.Author.Login
+
+	if ctx.ContentType == ContentDraftIssue {
+		return ctx, nil
+	}
+
+	comments, err := c.pageComments(commentsPageQuery, ctn.ID, ctn.Comments, opts.MaxComments)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Comments = comments
+	ctx.TotalComments = ctn.Comments.TotalCount
+	ctx.CommentsCapped = len(comments) < ctx.TotalComments
+
+	if ctx.ContentType == ContentPullRequest {
+		if err := c.fetchReviewThreads(ctx, ctn.ID, opts.MaxComments); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Extended {
+		ctx.Extended = true
+		if err := c.fetchExtendedContext(ctx, ctn.ID, opts); err != nil {
+			return nil, err
 		}
-		ctx.Comments = append(ctx.Comments, Comment{
-			Author:    login,
-			Body:      c.Body,
-			CreatedAt: c.CreatedAt,
-		})
 	}
-	ctx.CommentsCapped = ctn.Comments.PageInfo.HasNextPage
 	return ctx, nil
 }
+
+// commentPageSize returns how many comments to request next so that a capped
+// fetch never over-fetches. have is the number already collected.
+func commentPageSize(limit, have int) int {
+	if limit <= 0 || limit-have >= contextPageSize {
+		return contextPageSize
+	}
+	return limit - have
+}
+
+// pageComments follows a comments connection (on an Issue, PullRequest or
+// PullRequestReviewThread, selected by query) starting from an
+// already-fetched first page.
+func (c *Client) pageComments(query, nodeID string, first rawCommentsConn, limit int) ([]Comment, error) {
+	comments := first.comments()
+	conn := first
+	for conn.PageInfo.HasNextPage && (limit <= 0 || len(comments) < limit) {
+		variables := map[string]any{
+			"id":     nodeID,
+			"first":  commentPageSize(limit, len(comments)),
+			"cursor": conn.PageInfo.EndCursor,
+		}
+		var resp struct {
+			Node struct {
+				Comments rawCommentsConn `json:"comments"`
+			} `json:"node"`
+		}
+		if err := c.query(query, variables, &resp); err != nil {
+			return nil, fmt.Errorf("fetch comments page: %w", err)
+		}
+		conn = resp.Node.Comments
+		comments = append(comments, conn.comments()...)
+	}
+	if limit > 0 && len(comments) > limit {
+		comments = comments[:limit]
+	}
+	return comments, nil
+}
+
+// fetchReviewThreads pages every review thread of a pull request and every
+// comment within each thread, stopping once limit review comments have been
+// collected (limit <= 0 means no cap).
+func (c *Client) fetchReviewThreads(ctx *ItemContext, prID string, limit int) error {
+	collected := 0
+	var cursor *string
+	for {
+		variables := map[string]any{
+			"id":     prID,
+			"first":  commentPageSize(limit, collected),
+			"cursor": cursor,
+		}
+		var resp struct {
+			Node struct {
+				ReviewThreads struct {
+					TotalCount int `json:"totalCount"`
+					PageInfo   struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []rawReviewThread `json:"nodes"`
+				} `json:"reviewThreads"`
+			} `json:"node"`
+		}
+		if err := c.query(reviewThreadsPageQuery, variables, &resp); err != nil {
+			return fmt.Errorf("fetch review threads: %w", err)
+		}
+		ctx.TotalReviewThreads = resp.Node.ReviewThreads.TotalCount
+
+		for _, raw := range resp.Node.ReviewThreads.Nodes {
+			ctx.TotalReviewComments += raw.Comments.TotalCount
+			if limit > 0 && collected >= limit {
+				continue
+			}
+			remaining := 0
+			if limit > 0 {
+				remaining = limit - collected
+			}
+			comments, err := c.pageComments(threadCommentsPageQuery, raw.ID, raw.Comments, remaining)
+			if err != nil {
+				return err
+			}
+			line := raw.Line
+			if line == 0 {
+				line = raw.OriginalLine
+			}
+			ctx.ReviewThreads = append(ctx.ReviewThreads, ReviewThread{
+				Path:       raw.Path,
+				Line:       line,
+				IsResolved: raw.IsResolved,
+				IsOutdated: raw.IsOutdated,
+				Comments:   comments,
+			})
+			collected += len(comments)
+		}
+
+		// Past the cap, the thread count stands in for paging the rest.
+		if !resp.Node.ReviewThreads.PageInfo.HasNextPage || (limit > 0 && collected >= limit) {
+			break
+		}
+		next := resp.Node.ReviewThreads.PageInfo.EndCursor
+		cursor = &next
+	}
+	ctx.ReviewCommentsCapped = collected < ctx.TotalReviewComments || len(ctx.ReviewThreads) < ctx.TotalReviewThreads
+	return nil
+}
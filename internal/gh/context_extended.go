@@ -0,0 +1,377 @@
+package gh
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// DefaultMaxDiffBytes is the diff size cap used when ItemContextOptions leaves
+// MaxDiffBytes unset. Large enough for a typical PR, small enough not to drown
+// an LLM prompt.
+const DefaultMaxDiffBytes = 64 * 1024
+
+// diffMediaType asks the pulls endpoint for the unified diff.
+const diffMediaType = "application/vnd.github.v3.diff"
+
+type ChangedFile struct {
+	Path       string
+	Additions  int
+	Deletions  int
+	ChangeType string // ADDED, MODIFIED, DELETED, RENAMED, ...
+}
+
+type Review struct {
+	Author      string
+	State       string // APPROVED, CHANGES_REQUESTED, COMMENTED, ...
+	Body        string
+	SubmittedAt time.Time
+}
+
+type TimelineEventKind string
+
+const (
+	EventLabeled         TimelineEventKind = "LabeledEvent"
+	EventUnlabeled       TimelineEventKind = "UnlabeledEvent"
+	EventClosed          TimelineEventKind = "ClosedEvent"
+	EventReopened        TimelineEventKind = "ReopenedEvent"
+	EventMerged          TimelineEventKind = "MergedEvent"
+	EventCrossReferenced TimelineEventKind = "CrossReferencedEvent"
+)
+
+// TimelineEvent is one of the "key" events worth keeping in an AI context.
+// Subject carries the kind-specific detail: the label name, the closing PR or
+// commit, the merge commit, or the referencing issue/PR.
+type TimelineEvent struct {
+	Kind      TimelineEventKind
+	Actor     string
+	CreatedAt time.Time
+	Subject   string
+}
+
+// timelinePageQuery is formatted with the content typename (Issue or
+// PullRequest) and the itemTypes list relevant to it.
+const timelinePageQuery = `
+query TimelinePage($id: ID!, $cursor: String) {
+  node(id: $id) {
+    ... on %s {
+      timelineItems(first: 100, after: $cursor, itemTypes: [%s]) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          __typename
+          ... on LabeledEvent { createdAt actor { login } label { name } }
+          ... on UnlabeledEvent { createdAt actor { login } label { name } }
+          ... on ClosedEvent {
+            createdAt
+            actor { login }
+            closer {
+              __typename
+              ... on PullRequest { number }
+              ... on Commit { abbreviatedOid }
+            }
+          }
+          ... on ReopenedEvent { createdAt actor { login } }
+          ... on MergedEvent { createdAt actor { login } commit { abbreviatedOid } mergeRefName }
+          ... on CrossReferencedEvent {
+            createdAt
+            actor { login }
+            source {
+              __typename
+              ... on Issue { number title repository { nameWithOwner } }
+              ... on PullRequest { number title repository { nameWithOwner } }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+const reviewsPageQuery = `
+query ReviewsPage($id: ID!, $cursor: String) {
+  node(id: $id) {
+    ... on PullRequest {
+      reviews(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          author { login }
+          state
+          body
+          submittedAt
+        }
+      }
+    }
+  }
+}
+`
+
+const filesPageQuery = `
+query FilesPage($id: ID!, $cursor: String) {
+  node(id: $id) {
+    ... on PullRequest {
+      files(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          path
+          additions
+          deletions
+          changeType
+        }
+      }
+    }
+  }
+}
+`
+
+type rawPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type rawRefSource struct {
+	Typename   string `json:"__typename"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Repository *struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+}
+
+type rawTimelineNode struct {
+	Typename  string    `json:"__typename"`
+	CreatedAt time.Time `json:"createdAt"`
+	Actor     *rawLogin `json:"actor"`
+	Label     *struct {
+		Name string `json:"name"`
+	} `json:"label"`
+	Closer *struct {
+		Typename       string `json:"__typename"`
+		Number         int    `json:"number"`
+		AbbreviatedOid string `json:"abbreviatedOid"`
+	} `json:"closer"`
+	Commit *struct {
+		AbbreviatedOid string `json:"abbreviatedOid"`
+	} `json:"commit"`
+	MergeRefName string        `json:"mergeRefName"`
+	Source       *rawRefSource `json:"source"`
+}
+
+func (n rawTimelineNode) event() TimelineEvent {
+	ev := TimelineEvent{Kind: TimelineEventKind(n.Typename), CreatedAt: n.CreatedAt}
+	if n.Actor != nil {
+		ev.Actor = n.Actor.Login
+	}
+	switch ev.Kind {
+	case EventLabeled, EventUnlabeled:
+		if n.Label != nil {
+			ev.Subject = n.Label.Name
+		}
+	case EventClosed:
+		if n.Closer != nil {
+			switch n.Closer.Typename {
+			case "PullRequest":
+				ev.Subject = fmt.Sprintf("#%d", n.Closer.Number)
+			case "Commit":
+				ev.Subject = n.Closer.AbbreviatedOid
+			}
+		}
+	case EventMerged:
+		if n.Commit != nil {
+			ev.Subject = n.Commit.AbbreviatedOid
+		}
+		if n.MergeRefName != "" {
+			ev.Subject = strings.TrimSpace(ev.Subject + " into " + n.MergeRefName)
+		}
+	case EventCrossReferenced:
+		if n.Source != nil {
+			ref := fmt.Sprintf("#%d", n.Source.Number)
+			if n.Source.Repository != nil {
+				ref = n.Source.Repository.NameWithOwner + ref
+			}
+			ev.Subject = fmt.Sprintf("%s %s", ref, n.Source.Title)
+		}
+	}
+	return ev
+}
+
+// fetchExtendedContext fills the sections only the extended yank needs:
+// timeline for issues and PRs; changed files, reviews and the unified diff
+// for PRs.
+func (c *Client) fetchExtendedContext(ctx *ItemContext, contentID string, opts ItemContextOptions) error {
+	if err := c.fetchTimeline(ctx, contentID); err != nil {
+		return err
+	}
+	if ctx.ContentType != ContentPullRequest {
+		return nil
+	}
+	if err := c.fetchReviews(ctx, contentID); err != nil {
+		return err
+	}
+	if err := c.fetchChangedFiles(ctx, contentID); err != nil {
+		return err
+	}
+	if err := c.fetchDiff(ctx, opts.MaxDiffBytes); err != nil {
+		// Without the diff the rest is still worth yanking.
+		ctx.DiffError = err.Error()
+	}
+	return nil
+}
+
+func (c *Client) fetchTimeline(ctx *ItemContext, contentID string) error {
+	itemTypes := "LABELED_EVENT, UNLABELED_EVENT, CLOSED_EVENT, REOPENED_EVENT, CROSS_REFERENCED_EVENT"
+	if ctx.ContentType == ContentPullRequest {
+		itemTypes += ", MERGED_EVENT"
+	}
+	query := fmt.Sprintf(timelinePageQuery, ctx.ContentType, itemTypes)
+
+	var cursor *string
+	for {
+		var resp struct {
+			Node struct {
+				TimelineItems struct {
+					PageInfo rawPageInfo       `json:"pageInfo"`
+					Nodes    []rawTimelineNode `json:"nodes"`
+				} `json:"timelineItems"`
+			} `json:"node"`
+		}
+		variables := map[string]any{"id": contentID, "cursor": cursor}
+		if err := c.query(query, variables, &resp); err != nil {
+			return fmt.Errorf("fetch timeline: %w", err)
+		}
+		for _, n := range resp.Node.TimelineItems.Nodes {
+			ctx.Timeline = append(ctx.Timeline, n.event())
+		}
+		if !resp.Node.TimelineItems.PageInfo.HasNextPage {
+			return nil
+		}
+		next := resp.Node.TimelineItems.PageInfo.EndCursor
+		cursor = &next
+	}
+}
+
+func (c *Client) fetchReviews(ctx *ItemContext, prID string) error {
+	var cursor *string
+	for {
+		var resp struct {
+			Node struct {
+				Reviews struct {
+					PageInfo rawPageInfo `json:"pageInfo"`
+					Nodes    []struct {
+						Author      *rawLogin `json:"author"`
+						State       string    `json:"state"`
+						Body        string    `json:"body"`
+						SubmittedAt time.Time `json:"submittedAt"`
+					} `json:"nodes"`
+				} `json:"reviews"`
+			} `json:"node"`
+		}
+		variables := map[string]any{"id": prID, "cursor": cursor}
+		if err := c.query(reviewsPageQuery, variables, &resp); err != nil {
+			return fmt.Errorf("fetch reviews: %w", err)
+		}
+		for _, n := range resp.Node.Reviews.Nodes {
+			r := Review{State: n.State, Body: n.Body, SubmittedAt: n.SubmittedAt}
+			if n.Author != nil {
+				r.Author = n.Author.Login
+			}
+			ctx.Reviews = append(ctx.Reviews, r)
+		}
+		if !resp.Node.Reviews.PageInfo.HasNextPage {
+			return nil
+		}
+		next := resp.Node.Reviews.PageInfo.EndCursor
+		cursor = &next
+	}
+}
+
+func (c *Client) fetchChangedFiles(ctx *ItemContext, prID string) error {
+	var cursor *string
+	for {
+		var resp struct {
+			Node struct {
+				Files struct {
+					PageInfo rawPageInfo `json:"pageInfo"`
+					Nodes    []struct {
+						Path       string `json:"path"`
+						Additions  int    `json:"additions"`
+						Deletions  int    `json:"deletions"`
+						ChangeType string `json:"changeType"`
+					} `json:"nodes"`
+				} `json:"files"`
+			} `json:"node"`
+		}
+		variables := map[string]any{"id": prID, "cursor": cursor}
+		if err := c.query(filesPageQuery, variables, &resp); err != nil {
+			return fmt.Errorf("fetch changed files: %w", err)
+		}
+		for _, n := range resp.Node.Files.Nodes {
+			ctx.Files = append(ctx.Files, ChangedFile{
+				Path:       n.Path,
+				Additions:  n.Additions,
+				Deletions:  n.Deletions,
+				ChangeType: n.ChangeType,
+			})
+		}
+		if !resp.Node.Files.PageInfo.HasNextPage {
+			return nil
+		}
+		next := resp.Node.Files.PageInfo.EndCursor
+		cursor = &next
+	}
+}
+
+// fetchDiff downloads the PR's unified diff over REST (GraphQL does not expose
+// patches) and keeps at most maxBytes of it. The request goes through the
+// GraphQL client's transport, host and timeout, and is retried like a query.
+func (c *Client) fetchDiff(ctx *ItemContext, maxBytes int) error {
+	if ctx.RepoNameOwner == "" || ctx.Number == 0 {
+		return nil
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxDiffBytes
+	}
+
+	opts := c.opts
+	opts.Headers = maps.Clone(opts.Headers)
+	if opts.Headers == nil {
+		opts.Headers = map[string]string{}
+	}
+	opts.Headers["Accept"] = diffMediaType
+	rest, err := api.NewRESTClient(opts)
+	if err != nil {
+		return fmt.Errorf("rest client: %w", err)
+	}
+	var resp *http.Response
+	err = c.retry(func() error {
+		var err error
+		resp, err = rest.Request("GET", fmt.Sprintf("repos/%s/pulls/%d", ctx.RepoNameOwner, ctx.Number), nil)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("fetch diff: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read one byte past the cap so we can tell "exactly maxBytes" from
+	// "truncated".
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)+1))
+	if err != nil {
+		return fmt.Errorf("read diff: %w", err)
+	}
+	if len(raw) > maxBytes {
+		raw = raw[:maxBytes]
+		// Cut at the last complete line so the fence stays readable.
+		if i := strings.LastIndexByte(string(raw), '\n'); i > 0 {
+			raw = raw[:i+1]
+		}
+		ctx.DiffTruncated = true
+	}
+	ctx.Diff = string(raw)
+	return nil
+}
@@ -0,0 +1,76 @@
+package gh
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestFetchReviewThreads_StopsPagingAtTheCap(t *testing.T) {
+	t.Parallel()
+	transport := &scriptedTransport{responses: []*http.Response{
+		jsonResponse(200, `{"data":{"node":{"reviewThreads":{
+			"totalCount":120,
+			"pageInfo":{"hasNextPage":true,"endCursor":"T50"},
+			"nodes":[
+				{"id":"RT_1","path":"a.go","line":3,"comments":{"totalCount":4,"pageInfo":{"hasNextPage":true,"endCursor":"C2"},
+					"nodes":[{"body":"one","createdAt":"2026-01-01T00:00:00Z"},{"body":"two","createdAt":"2026-01-02T00:00:00Z"}]}},
+				{"id":"RT_2","path":"b.go","line":9,"comments":{"totalCount":1,"pageInfo":{"hasNextPage":false},
+					"nodes":[{"body":"three","createdAt":"2026-01-03T00:00:00Z"}]}}
+			]}}}}`),
+	}}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{gql: gql}
+
+	ctx := &ItemContext{}
+	if err := c.fetchReviewThreads(ctx, "PR_1", 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(transport.requests) != 1 {
+		t.Fatalf("sent %d requests, want the threads past the cap left unpaged", len(transport.requests))
+	}
+	if !strings.Contains(transport.requests[0], `"first":2`) {
+		t.Fatalf("request = %s, want the thread comments capped at 2", transport.requests[0])
+	}
+	if len(ctx.ReviewThreads) != 1 || len(ctx.ReviewThreads[0].Comments) != 2 {
+		t.Fatalf("threads = %+v, want the first thread's two comments", ctx.ReviewThreads)
+	}
+	if ctx.TotalReviewThreads != 120 || !ctx.ReviewCommentsCapped {
+		t.Fatalf("TotalReviewThreads = %d, capped = %v; want 120 and capped", ctx.TotalReviewThreads, ctx.ReviewCommentsCapped)
+	}
+}
+
+// scriptedTransport answers with responses in turn; a nil one times out.
+type scriptedTransport struct {
+	responses []*http.Response
+	requests  []string
+}
+
+func (s *scriptedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	s.requests = append(s.requests, string(body))
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	if resp == nil {
+		return nil, timeoutErr{}
+	}
+	resp.Request = req
+	return resp, nil
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
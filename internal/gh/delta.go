@@ -0,0 +1,167 @@
+package gh
+
+import (
+	"fmt"
+	"time"
+)
+
+// filteredItemsPageQuery is itemsPageQuery narrowed by a Projects filter
+// query, plus the unfiltered totalCount so a caller can tell whether items
+// were removed or missed, and the rate limit.
+const filteredItemsPageQuery = `
+query FilteredItemsPage($projectId: ID!, $query: String!, $cursor: String) {
+  rateLimit {
+    limit
+    remaining
+    resetAt
+    cost
+  }
+  node(id: $projectId) {
+    ... on ProjectV2 {
+      all: items(first: 1) {
+        totalCount
+      }
+      items(first: 100, after: $cursor, query: $query) {
+        totalCount
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        nodes {
+%s
+        }
+      }
+    }
+  }
+}
+`
+
+const itemIDsPageQuery = `
+query ItemIDsPage($projectId: ID!, $cursor: String) {
+  node(id: $projectId) {
+    ... on ProjectV2 {
+      items(first: 100, after: $cursor) {
+        pageInfo {
+          hasNextPage
+          endCursor
+        }
+        nodes {
+          id
+        }
+      }
+    }
+  }
+}
+`
+
+// ItemsDelta is what changed on a project since an earlier sync.
+type ItemsDelta struct {
+	// Items were updated at or after the requested time (and possibly a
+	// little before it: the filter only has day granularity).
+	Items []Item
+	// TotalItems is the size of the whole, unfiltered items connection.
+	TotalItems int
+	// RateLimit is the GraphQL budget left after the last page.
+	RateLimit RateLimit
+}
+
+// FetchItemsSince returns the items updated since the given time. Projects
+// filters compare whole days, so the window starts a day early to cover
+// timezones; re-sending an unchanged item is harmless for a merge.
+func (c *Client) FetchItemsSince(projectID, statusFieldID string, since time.Time) (*ItemsDelta, error) {
+	filter := "updated:>=" + since.UTC().AddDate(0, 0, -1).Format("2006-01-02")
+	delta := &ItemsDelta{}
+	cursor := ""
+	for {
+		page, err := c.fetchFilteredPage(projectID, statusFieldID, filter, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("fetch updated items: %w", err)
+		}
+		delta.Items = append(delta.Items, page.Items...)
+		delta.TotalItems = page.ProjectTotal
+		delta.RateLimit = page.RateLimit
+		if page.NextCursor == "" {
+			return delta, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// filteredPage is one page of a filtered items query.
+type filteredPage struct {
+	ItemsPage
+	ProjectTotal int // unfiltered item count
+	RateLimit    RateLimit
+}
+
+func (c *Client) fetchFilteredPage(projectID, statusFieldID, filter, cursor string) (*filteredPage, error) {
+	variables := map[string]any{
+		"projectId": projectID,
+		"query":     filter,
+		"cursor":    nil,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+	var resp struct {
+		RateLimit RateLimit `json:"rateLimit"`
+		Node      struct {
+			All struct {
+				TotalCount int `json:"totalCount"`
+			} `json:"all"`
+			Items rawItemsConn `json:"items"`
+		} `json:"node"`
+	}
+	if err := c.query(fmt.Sprintf(filteredItemsPageQuery, c.itemFields()), variables, &resp); err != nil {
+		return nil, err
+	}
+	page := &filteredPage{
+		ItemsPage:    ItemsPage{TotalItems: resp.Node.Items.TotalCount},
+		ProjectTotal: resp.Node.All.TotalCount,
+		RateLimit:    resp.RateLimit,
+	}
+	for _, n := range resp.Node.Items.Nodes {
+		page.Items = append(page.Items, decodeItem(n, statusFieldID))
+	}
+	if resp.Node.Items.PageInfo.HasNextPage {
+		page.NextCursor = resp.Node.Items.PageInfo.EndCursor
+	}
+	return page, nil
+}
+
+// FetchItemIDs lists the id of every item on the project. It is the cheap
+// sweep a delta sync falls back to when items may have been removed.
+func (c *Client) FetchItemIDs(projectID string) (map[string]struct{}, error) {
+	ids := make(map[string]struct{})
+	var cursor *string
+	for {
+		variables := map[string]any{
+			"projectId": projectID,
+			"cursor":    cursor,
+		}
+		var resp struct {
+			Node struct {
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID string `json:"id"`
+					} `json:"nodes"`
+				} `json:"items"`
+			} `json:"node"`
+		}
+		if err := c.query(itemIDsPageQuery, variables, &resp); err != nil {
+			return nil, fmt.Errorf("list item ids: %w", err)
+		}
+		for _, n := range resp.Node.Items.Nodes {
+			ids[n.ID] = struct{}{}
+		}
+		if !resp.Node.Items.PageInfo.HasNextPage {
+			return ids, nil
+		}
+		next := resp.Node.Items.PageInfo.EndCursor
+		cursor = &next
+	}
+}
@@ -3,42 +3,166 @@ package gh
 import (
 	"fmt"
 
-	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/repository"
 )
 
-func DetectOwnerFromCurrentRepo() (InitParams, error) {
+// DetectedRepo is what the repository in the current directory says about
+// which projects to show.
+type DetectedRepo struct {
+	// InitParams names the repository's owner.
+	InitParams
+	// Linked are the projects linked to the repository (repository.projectsV2),
+	// which may belong to another user or organization.
+	Linked []ProjectSummary
+	// LinkedErr is why Linked could not be listed.
+	LinkedErr error
+}
+
+// DetectOwnerFromCurrentRepo resolves the owner of the current repository
+// and, with withLinked, the projects linked to it. Only a project picker
+// needs those, so a launch naming the project skips the query. The lookups
+// retry like every other query and, with record set, are saved to that
+// --record directory.
+func DetectOwnerFromCurrentRepo(record string, withLinked bool) (DetectedRepo, error) {
 	repo, err := repository.Current()
 	if err != nil {
-		return InitParams{}, fmt.Errorf("detect current repository: %w", err)
+		return DetectedRepo{}, fmt.Errorf("detect current repository: %w", err)
+	}
+
+	c, err := newLookupClient(record)
+	if err != nil {
+		return DetectedRepo{}, err
 	}
+	params, err := c.resolveOwner(repo.Owner)
+	if err != nil {
+		return DetectedRepo{}, err
+	}
+	detected := DetectedRepo{InitParams: params}
+	if withLinked {
+		// Linked projects are only suggestions: a repository the token
+		// can't read them on still has an owner to fall back to.
+		detected.Linked, detected.LinkedErr = c.linkedProjects(repo.Owner, repo.Name)
+	}
+	return detected, nil
+}
 
-	gql, err := api.DefaultGraphQLClient()
+// ResolveOwner looks up whether login is a user or an organization,
+// recording the lookup like DetectOwnerFromCurrentRepo.
+func ResolveOwner(record, login string) (InitParams, error) {
+	c, err := newLookupClient(record)
 	if err != nil {
-		return InitParams{}, fmt.Errorf("graphql client: %w", err)
+		return InitParams{}, err
 	}
+	return c.resolveOwner(login)
+}
 
-	const q = `query($login: String!) {
-	  repositoryOwner(login: $login) { __typename }
-	}`
+const resolveOwnerQuery = `
+query ResolveOwner($login: String!) {
+  repositoryOwner(login: $login) { __typename }
+}
+`
+
+func (c *Client) resolveOwner(login string) (InitParams, error) {
 	var resp struct {
 		RepositoryOwner *struct {
 			Typename string `json:"__typename"`
 		} `json:"repositoryOwner"`
 	}
-	if err := gql.Do(q, map[string]any{"login": repo.Owner}, &resp); err != nil {
-		return InitParams{}, fmt.Errorf("resolve owner type for %q: %w", repo.Owner, err)
+	if err := c.query(resolveOwnerQuery, map[string]any{"login": login}, &resp); err != nil {
+		return InitParams{}, fmt.Errorf("resolve owner type for %q: %w", login, err)
 	}
 	if resp.RepositoryOwner == nil {
-		return InitParams{}, fmt.Errorf("owner %q not found", repo.Owner)
+		return InitParams{}, fmt.Errorf("owner %q not found", login)
 	}
 
 	switch resp.RepositoryOwner.Typename {
 	case "Organization":
-		return InitParams{OrgLogin: repo.Owner}, nil
+		return InitParams{OrgLogin: login}, nil
 	case "User":
-		return InitParams{UserLogin: repo.Owner}, nil
+		return InitParams{UserLogin: login}, nil
 	default:
-		return InitParams{}, fmt.Errorf("unsupported owner type %q for %q", resp.RepositoryOwner.Typename, repo.Owner)
+		return InitParams{}, fmt.Errorf("unsupported owner type %q for %q", resp.RepositoryOwner.Typename, login)
+	}
+}
+
+const linkedProjectsQuery = `
+query LinkedProjects($owner: String!, $name: String!, $first: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    projectsV2(first: $first, after: $after) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        id
+        number
+        title
+        url
+        owner {
+          __typename
+          ... on User { login }
+          ... on Organization { login }
+        }
+      }
+    }
+  }
+}
+`
+
+func (c *Client) linkedProjects(owner, name string) ([]ProjectSummary, error) {
+	var (
+		projects []ProjectSummary
+		after    *string
+	)
+	for {
+		var resp struct {
+			Repository *struct {
+				ProjectsV2 struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						ID     string `json:"id"`
+						Number int    `json:"number"`
+						Title  string `json:"title"`
+						URL    string `json:"url"`
+						Owner  struct {
+							Typename string `json:"__typename"`
+							Login    string `json:"login"`
+						} `json:"owner"`
+					} `json:"nodes"`
+				} `json:"projectsV2"`
+			} `json:"repository"`
+		}
+		variables := map[string]any{"owner": owner, "name": name, "first": 100, "after": after}
+		if err := c.query(linkedProjectsQuery, variables, &resp); err != nil {
+			return nil, fmt.Errorf("list projects linked to %s/%s: %w", owner, name, err)
+		}
+		if resp.Repository == nil {
+			return projects, nil
+		}
+
+		page := resp.Repository.ProjectsV2
+		for _, n := range page.Nodes {
+			ownerType := ClientTypeUser
+			if n.Owner.Typename == "Organization" {
+				ownerType = ClientTypeOrganization
+			}
+			projects = append(projects, ProjectSummary{
+				ID:         n.ID,
+				Number:     n.Number,
+				Title:      n.Title,
+				URL:        n.URL,
+				OwnerType:  ownerType,
+				OwnerLogin: n.Owner.Login,
+			})
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return projects, nil
+		}
+		cursor := page.PageInfo.EndCursor
+		after = &cursor
 	}
 }
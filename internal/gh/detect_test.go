@@ -0,0 +1,39 @@
+package gh
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestLinkedProjects_PagesAndRetries(t *testing.T) {
+	t.Parallel()
+	transport := &scriptedTransport{responses: []*http.Response{
+		jsonResponse(502, `{"message":"Bad Gateway"}`),
+		jsonResponse(200, `{"data":{"repository":{"projectsV2":{
+			"pageInfo":{"hasNextPage":true,"endCursor":"C1"},
+			"nodes":[{"id":"P_1","number":1,"title":"Roadmap","owner":{"__typename":"Organization","login":"acme"}}]}}}}`),
+		jsonResponse(200, `{"data":{"repository":{"projectsV2":{
+			"pageInfo":{"hasNextPage":false},
+			"nodes":[{"id":"P_2","number":1,"title":"Mine","owner":{"__typename":"User","login":"alice"}}]}}}}`),
+	}}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "x", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Client{gql: gql, sleep: func(time.Duration) {}}
+
+	got, err := c.linkedProjects("acme", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].OwnerType != ClientTypeOrganization || got[1].OwnerLogin != "alice" {
+		t.Fatalf("linked = %+v, want both pages with their owners", got)
+	}
+	if !strings.Contains(transport.requests[2], `"after":"C1"`) {
+		t.Fatalf("second page request = %s, want it to start after C1", transport.requests[2])
+	}
+}
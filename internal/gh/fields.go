@@ -0,0 +1,132 @@
+package gh
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldDataType mirrors GitHub's ProjectV2FieldType enum.
+type FieldDataType string
+
+const (
+	FieldText         FieldDataType = "TEXT"
+	FieldNumber       FieldDataType = "NUMBER"
+	FieldDate         FieldDataType = "DATE"
+	FieldSingleSelect FieldDataType = "SINGLE_SELECT"
+	FieldIteration    FieldDataType = "ITERATION"
+)
+
+type Iteration struct {
+	ID        string
+	Title     string
+	StartDate string
+}
+
+// Field is a project field with enough type information to build an update.
+// Built-in fields (Title, Assignees, Labels, ...) are reported too but carry
+// data types the update mutation does not accept.
+type Field struct {
+	ID         string
+	Name       string
+	DataType   FieldDataType
+	Options    []SingleSelectOption // SINGLE_SELECT only
+	Iterations []Iteration          // ITERATION only
+}
+
+// Editable reports whether updateProjectV2ItemFieldValue can set this field.
+func (f Field) Editable() bool {
+	switch f.DataType {
+	case FieldText, FieldNumber, FieldDate, FieldSingleSelect, FieldIteration:
+		return f.Name != "Title"
+	}
+	return false
+}
+
+const projectFieldsQuery = `
+query ProjectFields($projectId: ID!) {
+  node(id: $projectId) {
+    ... on ProjectV2 {
+      fields(first: 50) {
+        nodes {
+          ... on ProjectV2FieldCommon {
+            id
+            name
+            dataType
+          }
+          ... on ProjectV2SingleSelectField {
+            options {
+              id
+              name
+              color
+              description
+            }
+          }
+          ... on ProjectV2IterationField {
+            configuration {
+              iterations {
+                id
+                title
+                startDate
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// FetchFields lists every field of a project with its data type, options and
+// iterations.
+func (c *Client) FetchFields(projectID string) ([]Field, error) {
+	var resp struct {
+		Node struct {
+			Fields struct {
+				Nodes []struct {
+					ID            string               `json:"id"`
+					Name          string               `json:"name"`
+					DataType      string               `json:"dataType"`
+					Options       []SingleSelectOption `json:"options"`
+					Configuration *struct {
+						Iterations []struct {
+							ID        string `json:"id"`
+							Title     string `json:"title"`
+							StartDate string `json:"startDate"`
+						} `json:"iterations"`
+					} `json:"configuration"`
+				} `json:"nodes"`
+			} `json:"fields"`
+		} `json:"node"`
+	}
+	if err := c.query(projectFieldsQuery, map[string]any{"projectId": projectID}, &resp); err != nil {
+		return nil, fmt.Errorf("fetch project fields: %w", err)
+	}
+
+	fields := make([]Field, 0, len(resp.Node.Fields.Nodes))
+	for _, n := range resp.Node.Fields.Nodes {
+		f := Field{
+			ID:       n.ID,
+			Name:     n.Name,
+			DataType: FieldDataType(n.DataType),
+			Options:  n.Options,
+		}
+		if n.Configuration != nil {
+			for _, it := range n.Configuration.Iterations {
+				f.Iterations = append(f.Iterations, Iteration(it))
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// FindField returns the field with the given name (case-insensitive).
+func FindField(fields []Field, name string) (Field, bool) {
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
@@ -0,0 +1,404 @@
+package gh
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A fixture directory holds one JSON file per exchange (GraphQL, plus the
+// REST diff download), numbered in the order the requests were sent, plus fixture.json naming the owner so a
+// replay needs no owner detection. Only request bodies, status codes,
+// rate-limit headers and response bodies (or the transport error) are
+// stored, along with each request's method, path and Accept header; the
+// token never is.
+// Board contents are, so share fixtures like you would a screenshot.
+
+// ErrNoFixture is returned by a replay for a request that was never recorded.
+var ErrNoFixture = errors.New("no recorded response")
+
+const (
+	// Version 2 added the method, path and Accept header to exchanges;
+	// version 3 lists every recording client's owner.
+	fixtureVersion  = 3
+	fixtureManifest = "fixture.json"
+)
+
+type fixtureMeta struct {
+	Version int `json:"version"`
+	// Owners are the owners of the clients that recorded, in the order
+	// they started; a replay defaults to the first.
+	Owners     []fixtureOwner `json:"owners"`
+	RecordedAt time.Time      `json:"recordedAt"`
+}
+
+type fixtureOwner struct {
+	Type  ClientType `json:"type"`
+	Login string     `json:"login"`
+}
+
+type exchange struct {
+	Method  string          `json:"method"`
+	Path    string          `json:"path"` // with the query string
+	Accept  string          `json:"accept,omitempty"`
+	Request json.RawMessage `json:"request,omitempty"` // canonical {query, variables}
+	Status  int             `json:"status"`
+	Header  http.Header     `json:"header,omitempty"`
+	// Response holds a JSON body; anything else (an HTML error page) goes
+	// to ResponseText.
+	Response     json.RawMessage `json:"response,omitempty"`
+	ResponseText string          `json:"responseText,omitempty"`
+	// Error is a transport error (a timeout, a dropped connection) that
+	// came instead of a response; Transient says retryDelay retried it.
+	Error     string `json:"error,omitempty"`
+	Transient bool   `json:"transient,omitempty"`
+}
+
+// recordedHeaders are the response headers retryDelay looks at.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-Ratelimit-Limit", "X-Ratelimit-Remaining", "X-Ratelimit-Reset"}
+
+// recorder is an http.RoundTripper that saves every exchange one client
+// forwards to next.
+type recorder struct {
+	rec  *recording
+	next http.RoundTripper
+}
+
+// recording is one directory being recorded into. Every client of the run
+// that records there (the owner lookup, a project of another owner picked
+// mid-run) shares it, so exchanges keep one numbering and the manifest
+// lists each client's owner.
+type recording struct {
+	dir string
+
+	mu   sync.Mutex
+	seq  int
+	meta fixtureMeta
+}
+
+// recordings holds the recording of each directory in this process.
+var recordings = struct {
+	sync.Mutex
+	byDir map[string]*recording
+}{byDir: map[string]*recording{}}
+
+// newRecorder records a client of owner/login into dir. The first one in a
+// run starts afresh, removing exchanges left by an earlier run; later ones
+// add their owner to the manifest. An empty login (the lookup that finds
+// the owner) adds none.
+func newRecorder(dir string, owner ClientType, login string, next http.RoundTripper) (*recorder, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	recordings.Lock()
+	rec, ok := recordings.byDir[abs]
+	if !ok {
+		if rec, err = startRecording(dir); err != nil {
+			recordings.Unlock()
+			return nil, err
+		}
+		recordings.byDir[abs] = rec
+	}
+	recordings.Unlock()
+
+	if login != "" {
+		if err := rec.addOwner(fixtureOwner{Type: owner, Login: login}); err != nil {
+			return nil, err
+		}
+	}
+	return &recorder{rec: rec, next: next}, nil
+}
+
+func startRecording(dir string) (*recording, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	old, err := exchangeFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	for _, f := range old {
+		if err := os.Remove(f); err != nil {
+			return nil, fmt.Errorf("record: %w", err)
+		}
+	}
+	rec := &recording{dir: dir, meta: fixtureMeta{Version: fixtureVersion, RecordedAt: time.Now().UTC()}}
+	if err := writeJSON(filepath.Join(dir, fixtureManifest), rec.meta); err != nil {
+		return nil, fmt.Errorf("record: %w", err)
+	}
+	return rec, nil
+}
+
+func (r *recording) addOwner(o fixtureOwner) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.Contains(r.meta.Owners, o) {
+		return nil
+	}
+	r.meta.Owners = append(r.meta.Owners, o)
+	if err := writeJSON(filepath.Join(r.dir, fixtureManifest), r.meta); err != nil {
+		return fmt.Errorf("record: %w", err)
+	}
+	return nil
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		ex := exchange{
+			Method:    req.Method,
+			Path:      req.URL.RequestURI(),
+			Accept:    req.Header.Get("Accept"),
+			Request:   canonicalRequest(reqBody),
+			Error:     err.Error(),
+			Transient: transientNetError(err),
+		}
+		if saveErr := r.rec.save(ex); saveErr != nil {
+			return nil, saveErr
+		}
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ex := exchange{
+		Method:  req.Method,
+		Path:    req.URL.RequestURI(),
+		Accept:  req.Header.Get("Accept"),
+		Request: canonicalRequest(reqBody),
+		Status:  resp.StatusCode,
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Values(h); len(v) > 0 {
+			if ex.Header == nil {
+				ex.Header = http.Header{}
+			}
+			ex.Header[h] = v
+		}
+	}
+	if json.Valid(respBody) {
+		ex.Response = respBody
+	} else {
+		ex.ResponseText = string(respBody)
+	}
+
+	if err := r.rec.save(ex); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *recording) save(ex exchange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	if err := writeJSON(filepath.Join(r.dir, fmt.Sprintf("%04d.json", r.seq)), ex); err != nil {
+		return fmt.Errorf("record: %w", err)
+	}
+	return nil
+}
+
+// replayer is an http.RoundTripper that answers from a fixture directory.
+// Identical requests get their recorded responses in order, and the last one
+// again once those run out, so retries and re-syncs replay as they happened.
+type replayer struct {
+	meta fixtureMeta
+
+	mu        sync.Mutex
+	responses map[string][]exchange
+}
+
+func newReplayer(dir string) (*replayer, error) {
+	var meta fixtureMeta
+	if err := readJSON(filepath.Join(dir, fixtureManifest), &meta); err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	if meta.Version != fixtureVersion {
+		return nil, fmt.Errorf("replay: %s has fixture version %d, want %d", dir, meta.Version, fixtureVersion)
+	}
+	files, err := exchangeFiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	r := &replayer{meta: meta, responses: map[string][]exchange{}}
+	for _, f := range files {
+		var ex exchange
+		if err := readJSON(f, &ex); err != nil {
+			return nil, fmt.Errorf("replay: %w", err)
+		}
+		key := exchangeKey(ex.Method, ex.Path, ex.Accept, ex.Request)
+		r.responses[key] = append(r.responses[key], ex)
+	}
+	return r, nil
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	key := exchangeKey(req.Method, req.URL.RequestURI(), req.Header.Get("Accept"), body)
+
+	r.mu.Lock()
+	queue := r.responses[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		what := req.Method + " " + req.URL.Path
+		if len(body) > 0 {
+			what = operationName(body)
+		}
+		return nil, fmt.Errorf("%w for %s", ErrNoFixture, what)
+	}
+	ex := queue[0]
+	if len(queue) > 1 {
+		r.responses[key] = queue[1:]
+	}
+	r.mu.Unlock()
+
+	if ex.Error != "" {
+		return nil, replayedError{msg: ex.Error, transient: ex.Transient}
+	}
+	respBody := []byte(ex.ResponseText)
+	if len(ex.Response) > 0 {
+		respBody = ex.Response
+	}
+	header := ex.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode:    ex.Status,
+		Status:        fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// replayedError is a recorded transport error. It reports itself as a
+// timeout when the original was transient, so a replay retries it too.
+type replayedError struct {
+	msg       string
+	transient bool
+}
+
+func (e replayedError) Error() string   { return e.msg }
+func (e replayedError) Timeout() bool   { return e.transient }
+func (e replayedError) Temporary() bool { return e.transient }
+
+// exchangeFiles lists the numbered exchange files in dir in the order they
+// were recorded; past 9999 the names grow a digit, so the order is numeric.
+func exchangeFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	seqs := map[string]int{}
+	exchanges := files[:0]
+	for _, f := range files {
+		n, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(f), ".json"))
+		if err != nil {
+			continue // the manifest
+		}
+		seqs[f] = n
+		exchanges = append(exchanges, f)
+	}
+	slices.SortFunc(exchanges, func(a, b string) int { return seqs[a] - seqs[b] })
+	return exchanges, nil
+}
+
+// sinceDateRE finds the date of a delta sync's filter; encoding/json
+// escapes the ">".
+var sinceDateRE = regexp.MustCompile(`updated:(?:>|\\u003e)=\d{4}-\d{2}-\d{2}`)
+
+// exchangeKey identifies a request for replay. The body alone would not do:
+// every REST GET has none, and the same URL can be asked for as JSON or as
+// a diff. The date of a delta sync's filter is left out, so a recording
+// still answers the sync on a later day.
+func exchangeKey(method, path, accept string, body []byte) string {
+	req := sinceDateRE.ReplaceAllLiteralString(string(canonicalRequest(body)), "updated:>=DATE")
+	return method + " " + path + " " + accept + "\n" + req
+}
+
+// canonicalRequest re-encodes a GraphQL request body with sorted keys, so
+// a replay matches regardless of how the variables map was serialised.
+func canonicalRequest(body []byte) json.RawMessage {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+var operationRE = regexp.MustCompile(`^\s*(?:query|mutation)\s+(\w+)`)
+
+// operationName names a request in replay errors.
+func operationName(body []byte) string {
+	var req struct {
+		Query string `json:"query"`
+	}
+	if json.Unmarshal(body, &req) == nil {
+		if m := operationRE.FindStringSubmatch(req.Query); m != nil {
+			return m[1]
+		}
+		if q := strings.TrimSpace(req.Query); q != "" {
+			return strings.SplitN(q, "\n", 2)[0]
+		}
+	}
+	return "request"
+}
+
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
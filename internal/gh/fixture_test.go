@@ -0,0 +1,268 @@
+package gh
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/google/go-cmp/cmp"
+)
+
+const nodeQuery = "query Node($id: ID!) {\n  node(id: $id) { id }\n}"
+
+func queryNode(t *testing.T, c *Client, id string) (string, error) {
+	t.Helper()
+	var resp struct {
+		Node struct {
+			ID string `json:"id"`
+		} `json:"node"`
+	}
+	err := c.query(nodeQuery, map[string]any{"id": id}, &resp)
+	return resp.Node.ID, err
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	upstream := &scriptedTransport{responses: []*http.Response{
+		jsonResponse(200, `{"data":{"node":{"id":"first"}}}`),
+		jsonResponse(200, `{"data":{"node":{"id":"second"}}}`),
+	}}
+	rec, err := newRecorder(dir, ClientTypeOrganization, "acme", upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "secret-token", Transport: rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recording := &Client{gql: gql}
+	for _, want := range []string{"first", "second"} {
+		if got, err := queryNode(t, recording, "N_1"); err != nil || got != want {
+			t.Fatalf("recording: got %q, %v; want %q", got, err, want)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Fatalf("fixture files = %v, want manifest and two exchanges", files)
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		if strings.Contains(string(data), "secret-token") {
+			t.Fatalf("%s contains the auth token", f)
+		}
+	}
+
+	replaying, err := NewClient(InitParams{Replay: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replaying.ClientType != ClientTypeOrganization || replaying.Login != "acme" {
+		t.Fatalf("owner = %s %q, want the recorded organization", replaying.ClientType, replaying.Login)
+	}
+	for _, want := range []string{"first", "second", "second"} {
+		if got, err := queryNode(t, replaying, "N_1"); err != nil || got != want {
+			t.Fatalf("replay: got %q, %v; want %q", got, err, want)
+		}
+	}
+	if _, err := queryNode(t, replaying, "N_2"); !errors.Is(err, ErrNoFixture) {
+		t.Fatalf("unrecorded request: err = %v, want ErrNoFixture", err)
+	}
+}
+
+func TestRecord_EveryClientOfTheRun(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	clients := []struct {
+		owner ClientType
+		login string // "" for the lookup that finds the owner
+		reply string
+	}{
+		{"", "", "lookup"},
+		{ClientTypeUser, "alice", "mine"},
+		{ClientTypeOrganization, "acme", "theirs"},
+	}
+	for _, c := range clients {
+		upstream := &scriptedTransport{responses: []*http.Response{jsonResponse(200, `{"data":{"node":{"id":"`+c.reply+`"}}}`)}}
+		rec, err := newRecorder(dir, c.owner, c.login, upstream)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "x", Transport: rec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Each client's requests go to its own transport.
+		if got, err := queryNode(t, &Client{gql: gql}, "N_"+c.reply); err != nil || got != c.reply {
+			t.Fatalf("recording %s: got %q, %v", c.reply, got, err)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "[0-9][0-9][0-9][0-9].json"))
+	if len(files) != 3 {
+		t.Fatalf("exchanges = %v, want every client's request", files)
+	}
+	var meta fixtureMeta
+	if err := readJSON(filepath.Join(dir, fixtureManifest), &meta); err != nil {
+		t.Fatal(err)
+	}
+	want := []fixtureOwner{{ClientTypeUser, "alice"}, {ClientTypeOrganization, "acme"}}
+	if diff := cmp.Diff(want, meta.Owners); diff != "" {
+		t.Fatalf("manifest owners (-want +got):\n%s", diff)
+	}
+
+	// A replay defaults to the first owner and can be pointed at another.
+	replaying, err := NewClient(InitParams{Replay: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replaying.ClientType != ClientTypeUser || replaying.Login != "alice" {
+		t.Fatalf("replay owner = %s %q, want the first client's", replaying.ClientType, replaying.Login)
+	}
+	acme, err := NewClient(InitParams{Replay: dir, OrgLogin: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := queryNode(t, acme, "N_theirs"); err != nil || got != "theirs" {
+		t.Fatalf("replay for acme: got %q, %v", got, err)
+	}
+}
+
+func TestReplay_ServesRecordedErrors(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	notFound := &http.Response{StatusCode: 404, Header: http.Header{"Content-Type": {"text/html"}}, Body: io.NopCloser(strings.NewReader("<html>Not Found</html>"))}
+	rec, err := newRecorder(dir, ClientTypeUser, "alice", &scriptedTransport{responses: []*http.Response{notFound}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queryNode(t, &Client{gql: gql}, "N_1"); err == nil {
+		t.Fatal("recording: want the 404")
+	}
+
+	c, err := NewClient(InitParams{Replay: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = queryNode(t, c, "N_1")
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != 404 {
+		t.Fatalf("err = %v, want the recorded 404", err)
+	}
+}
+
+func TestReplay_RetriesRecordedTimeouts(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	upstream := &scriptedTransport{responses: []*http.Response{nil, jsonResponse(200, `{"data":{"node":{"id":"N_1"}}}`)}}
+	rec, err := newRecorder(dir, ClientTypeUser, "alice", upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := queryNode(t, &Client{gql: gql, sleep: func(time.Duration) {}}, "N_1"); err != nil || got != "N_1" {
+		t.Fatalf("recording: got %q, %v; want N_1 after a retry", got, err)
+	}
+
+	replaying, err := NewClient(InitParams{Replay: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	retries := 0
+	replaying.sleep = func(time.Duration) { retries++ }
+	if got, err := queryNode(t, replaying, "N_1"); err != nil || got != "N_1" || retries != 1 {
+		t.Fatalf("replay: got %q, %v after %d retries; want N_1 after one", got, err, retries)
+	}
+}
+
+func TestReplay_OrdersExchangesPast9999(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	upstream := &scriptedTransport{responses: []*http.Response{
+		jsonResponse(200, `{"data":{"node":{"id":"first"}}}`),
+		jsonResponse(200, `{"data":{"node":{"id":"second"}}}`),
+	}}
+	rec, err := newRecorder(dir, ClientTypeUser, "alice", upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.rec.seq = 9998
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := queryNode(t, &Client{gql: gql}, "N_1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "10000.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	replaying, err := NewClient(InitParams{Replay: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"first", "second"} {
+		if got, err := queryNode(t, replaying, "N_1"); err != nil || got != want {
+			t.Fatalf("replay: got %q, %v; want %q", got, err, want)
+		}
+	}
+}
+
+func TestExchangeKey_IgnoresDeltaSyncDate(t *testing.T) {
+	t.Parallel()
+	body := func(day string) []byte {
+		return []byte(`{"query":"query Q { x }","variables":{"filter":"updated:>=` + day + `"}}`)
+	}
+	if exchangeKey("POST", "/graphql", "", body("2026-01-01")) != exchangeKey("POST", "/graphql", "", body("2026-03-15")) {
+		t.Error("delta syncs on different days should replay the same recording")
+	}
+}
+
+func TestRecordThenReplay_Diff(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	diff := &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("diff --git a/x b/x\n"))}
+	rec, err := newRecorder(dir, ClientTypeOrganization, "acme", &scriptedTransport{responses: []*http.Response{diff}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	recording := &Client{opts: api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: rec}}
+	if err := recording.fetchDiff(&ItemContext{RepoNameOwner: "acme/app", Number: 7}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	replaying, err := NewClient(InitParams{Replay: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := &ItemContext{RepoNameOwner: "acme/app", Number: 7}
+	if err := replaying.fetchDiff(ctx, 0); err != nil || ctx.Diff != "diff --git a/x b/x\n" {
+		t.Fatalf("replayed diff = %q, %v", ctx.Diff, err)
+	}
+	// Same path, no body, other Accept header: not the recorded exchange.
+	rest, err := api.NewRESTClient(replaying.opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rest.Request("GET", "repos/acme/app/pulls/7", nil); !errors.Is(err, ErrNoFixture) {
+		t.Fatalf("JSON request for the recorded diff URL: err = %v, want ErrNoFixture", err)
+	}
+}
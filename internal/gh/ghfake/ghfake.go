@@ -0,0 +1,454 @@
+// Package ghfake is an in-memory GitHub project for tests. Backend answers
+// the same calls as *gh.Client from a project held in memory, pages it like
+// the API does, applies mutations to it, and can be scripted to add latency
+// and to fail specific calls.
+package ghfake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// DefaultPageSize matches the page size of the real board queries.
+const DefaultPageSize = 100
+
+// ErrScripted is the failure FailNext queues when given no error.
+var ErrScripted = errors.New("ghfake: scripted failure")
+
+// Backend serves one project. The zero value is not usable; call New.
+type Backend struct {
+	// PageSize is the number of items per page. 0 means DefaultPageSize.
+	PageSize int
+	// Latency delays every call, so tests can observe loading states.
+	Latency time.Duration
+	// Now stamps UpdatedAt on changed items. nil means time.Now.
+	Now func() time.Time
+
+	mu       sync.Mutex
+	project  gh.Project
+	contexts map[string]*gh.ItemContext
+	failures map[string][]error
+	calls    []string
+	rate     gh.RateLimit
+}
+
+// New returns a backend serving a copy of p.
+func New(p *gh.Project) *Backend {
+	b := &Backend{
+		project:  *p,
+		contexts: map[string]*gh.ItemContext{},
+		failures: map[string][]error{},
+	}
+	b.project.Items = cloneItems(p.Items)
+	return b
+}
+
+// FailNext makes the next calls of method (e.g. "FetchItemsPage") fail, one
+// call per error, before it answers normally again. Without errs it fails
+// once with ErrScripted. FetchPartitions consumes one error per page.
+func (b *Backend) FailNext(method string, errs ...error) {
+	if len(errs) == 0 {
+		errs = []error{ErrScripted}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures[method] = append(b.failures[method], errs...)
+}
+
+// SetContext sets what FetchItemContext returns for itemID. Items without
+// one get a context built from the item itself.
+func (b *Backend) SetContext(itemID string, ctx *gh.ItemContext) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.contexts[itemID] = ctx
+}
+
+// SetRateLimit sets the budget RateLimit and FetchItemsSince report.
+func (b *Backend) SetRateLimit(rl gh.RateLimit) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate = rl
+}
+
+// Calls lists the methods called so far, in order.
+func (b *Backend) Calls() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.calls)
+}
+
+// Project returns a copy of the project as the server currently has it.
+func (b *Backend) Project() *gh.Project {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := b.project
+	p.Items = cloneItems(b.project.Items)
+	return &p
+}
+
+// AddItem adds an item, as if someone else created it.
+func (b *Backend) AddItem(it gh.Item) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	it.UpdatedAt = b.now()
+	b.project.Items = append(b.project.Items, it)
+}
+
+// RemoveItem deletes an item, as if someone else removed it.
+func (b *Backend) RemoveItem(itemID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.project.Items = slices.DeleteFunc(b.project.Items, func(it gh.Item) bool { return it.ID == itemID })
+}
+
+// SetStatus moves an item without going through UpdateItemStatus, as if
+// someone else did it.
+func (b *Backend) SetStatus(itemID, optionID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.setOption(itemID, b.project.Status.ID, optionID)
+}
+
+func (b *Backend) BootstrapBySpec(spec gh.ProjectSpec) (*gh.BootstrapResult, error) {
+	if err := b.begin("BootstrapBySpec"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case spec.Number > 0 && spec.Number != b.project.Number:
+		return nil, fmt.Errorf("%w: no project #%d", gh.ErrProjectNotFound, spec.Number)
+	case spec.Number == 0 && spec.Title != b.project.Title:
+		return nil, fmt.Errorf("%w: no project with exact title %q", gh.ErrProjectNotFound, spec.Title)
+	}
+	items, next := b.page(b.project.Items, 0)
+	p := b.project
+	p.Items = items
+	return &gh.BootstrapResult{Project: &p, NextCursor: next, TotalItems: len(b.project.Items)}, nil
+}
+
+func (b *Backend) FetchItemsPage(projectID, statusFieldID, cursor string) (*gh.ItemsPage, error) {
+	if err := b.begin("FetchItemsPage"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(projectID, statusFieldID); err != nil {
+		return nil, err
+	}
+	offset, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	items, next := b.page(b.project.Items, offset)
+	return &gh.ItemsPage{Items: items, NextCursor: next, TotalItems: len(b.project.Items)}, nil
+}
+
+// FetchPartitions understands the filters gh.StatusPartitions builds. Pages
+// are delivered one goroutine per filter; concurrency is not enforced.
+func (b *Backend) FetchPartitions(ctx context.Context, projectID, statusFieldID string, filters []string, _ int) <-chan gh.PartitionPage {
+	out := make(chan gh.PartitionPage)
+	var wg sync.WaitGroup
+	for _, filter := range filters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			send := func(p gh.PartitionPage) bool {
+				select {
+				case out <- p:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			offset := 0
+			for {
+				if err := b.begin("FetchPartitions"); err != nil {
+					send(gh.PartitionPage{Filter: filter, Err: fmt.Errorf("fetch %s: %w", filter, err)})
+					return
+				}
+				b.mu.Lock()
+				err := b.check(projectID, statusFieldID)
+				var items []gh.Item
+				next := ""
+				if err == nil {
+					items, next = b.page(b.matching(filter), offset)
+				}
+				b.mu.Unlock()
+				if err != nil {
+					send(gh.PartitionPage{Filter: filter, Err: err})
+					return
+				}
+				if !send(gh.PartitionPage{Filter: filter, Items: items}) || next == "" {
+					return
+				}
+				offset, _ = parseCursor(next)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FetchItemsSince compares timestamps exactly, unlike GitHub's day-granular
+// filter.
+func (b *Backend) FetchItemsSince(projectID, statusFieldID string, since time.Time) (*gh.ItemsDelta, error) {
+	if err := b.begin("FetchItemsSince"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(projectID, statusFieldID); err != nil {
+		return nil, err
+	}
+	var items []gh.Item
+	for _, it := range b.project.Items {
+		if !it.UpdatedAt.Before(since) {
+			items = append(items, cloneItem(it))
+		}
+	}
+	return &gh.ItemsDelta{Items: items, TotalItems: len(b.project.Items), RateLimit: b.rate}, nil
+}
+
+func (b *Backend) FetchItemIDs(projectID string) (map[string]struct{}, error) {
+	if err := b.begin("FetchItemIDs"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.check(projectID, b.project.Status.ID); err != nil {
+		return nil, err
+	}
+	ids := make(map[string]struct{}, len(b.project.Items))
+	for _, it := range b.project.Items {
+		ids[it.ID] = struct{}{}
+	}
+	return ids, nil
+}
+
+func (b *Backend) FetchItemBody(itemID string) (string, error) {
+	if err := b.begin("FetchItemBody"); err != nil {
+		return "", err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	it, ok := b.item(itemID)
+	if !ok {
+		return "", fmt.Errorf("fetch item body: no item %s", itemID)
+	}
+	return it.Body, nil
+}
+
+func (b *Backend) FetchItemContext(itemID string, _ gh.ItemContextOptions) (*gh.ItemContext, error) {
+	if err := b.begin("FetchItemContext"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ctx, ok := b.contexts[itemID]; ok {
+		return ctx, nil
+	}
+	it, ok := b.item(itemID)
+	if !ok {
+		return nil, fmt.Errorf("fetch item context: no item %s", itemID)
+	}
+	return &gh.ItemContext{
+		ContentType:   it.ContentType,
+		RepoNameOwner: it.Repository,
+		Number:        it.Number,
+		Title:         it.Title,
+		Body:          it.Body,
+		URL:           it.URL,
+		Assignees:     slices.Clone(it.Assignees),
+		Labels:        slices.Clone(it.Labels),
+	}, nil
+}
+
+func (b *Backend) UpdateItemStatus(projectID, itemID, fieldID, optionID string) error {
+	if err := b.begin("UpdateItemStatus"); err != nil {
+		return fmt.Errorf("update item field: %w", err)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if projectID != b.project.ID {
+		return fmt.Errorf("update item field: ghfake: unknown project %q", projectID)
+	}
+	if err := b.setOption(itemID, fieldID, optionID); err != nil {
+		return fmt.Errorf("update item field: %w", err)
+	}
+	return nil
+}
+
+// ListProjects lists the one project served.
+func (b *Backend) ListProjects() ([]gh.ProjectSummary, error) {
+	if err := b.begin("ListProjects"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p := b.project
+	return []gh.ProjectSummary{{ID: p.ID, Number: p.Number, Title: p.Title, URL: p.URL}}, nil
+}
+
+func (b *Backend) RateLimit() gh.RateLimit {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate
+}
+
+// begin records a call, waits out the latency and pops a scripted failure.
+func (b *Backend) begin(method string) error {
+	if b.Latency > 0 {
+		time.Sleep(b.Latency)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, method)
+	if q := b.failures[method]; len(q) > 0 {
+		b.failures[method] = q[1:]
+		return q[0]
+	}
+	return nil
+}
+
+func (b *Backend) check(projectID, statusFieldID string) error {
+	if projectID != b.project.ID {
+		return fmt.Errorf("ghfake: unknown project %q", projectID)
+	}
+	if statusFieldID != b.project.Status.ID {
+		return fmt.Errorf("ghfake: unknown field %q", statusFieldID)
+	}
+	return nil
+}
+
+func (b *Backend) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+func (b *Backend) item(itemID string) (*gh.Item, bool) {
+	for i := range b.project.Items {
+		if b.project.Items[i].ID == itemID {
+			return &b.project.Items[i], true
+		}
+	}
+	return nil, false
+}
+
+// setOption sets a SingleSelect field (Status or any of Project.Fields) on
+// an item.
+func (b *Backend) setOption(itemID, fieldID, optionID string) error {
+	it, ok := b.item(itemID)
+	if !ok {
+		return fmt.Errorf("ghfake: no item %s", itemID)
+	}
+	field := b.project.Status
+	if fieldID != field.ID {
+		i := slices.IndexFunc(b.project.Fields, func(f gh.SingleSelectField) bool { return f.ID == fieldID })
+		if i < 0 {
+			return fmt.Errorf("ghfake: unknown field %q", fieldID)
+		}
+		field = b.project.Fields[i]
+	}
+	name := ""
+	if optionID != "" {
+		i := slices.IndexFunc(field.Options, func(o gh.SingleSelectOption) bool { return o.ID == optionID })
+		if i < 0 {
+			return fmt.Errorf("ghfake: no option %s on %s", optionID, field.Name)
+		}
+		name = field.Options[i].Name
+	}
+	if field.ID == b.project.Status.ID {
+		it.StatusOptionID = optionID
+	}
+	it.Fields = maps.Clone(it.Fields)
+	if it.Fields == nil {
+		it.Fields = map[string]string{}
+	}
+	if name == "" {
+		delete(it.Fields, field.Name)
+	} else {
+		it.Fields[field.Name] = name
+	}
+	it.UpdatedAt = b.now()
+	return nil
+}
+
+// matching returns the items a gh.StatusPartitions filter selects.
+func (b *Backend) matching(filter string) []gh.Item {
+	want := ""
+	if !strings.HasPrefix(filter, "no:") {
+		_, name, _ := strings.Cut(filter, ":")
+		name = strings.Trim(name, `"`)
+		i := slices.IndexFunc(b.project.Status.Options, func(o gh.SingleSelectOption) bool { return o.Name == name })
+		if i < 0 {
+			return nil
+		}
+		want = b.project.Status.Options[i].ID
+	}
+	var items []gh.Item
+	for _, it := range b.project.Items {
+		if it.StatusOptionID == want {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// page cuts one page from items starting at offset and returns it with the
+// cursor for the next page.
+func (b *Backend) page(items []gh.Item, offset int) ([]gh.Item, string) {
+	size := b.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	end := min(offset+size, len(items))
+	if offset >= end {
+		return nil, ""
+	}
+	next := ""
+	if end < len(items) {
+		next = "offset:" + strconv.Itoa(end)
+	}
+	return cloneItems(items[offset:end]), next
+}
+
+func parseCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(cursor, "offset:"))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("ghfake: bad cursor %q", cursor)
+	}
+	return n, nil
+}
+
+func cloneItems(items []gh.Item) []gh.Item {
+	out := make([]gh.Item, len(items))
+	for i, it := range items {
+		out[i] = cloneItem(it)
+	}
+	return out
+}
+
+func cloneItem(it gh.Item) gh.Item {
+	it.Assignees = slices.Clone(it.Assignees)
+	it.Labels = slices.Clone(it.Labels)
+	it.Fields = maps.Clone(it.Fields)
+	return it
+}
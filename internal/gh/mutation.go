@@ -2,25 +2,68 @@ package gh
 
 import "fmt"
 
-const updateSingleSelectMutation = `
-mutation UpdateStatus($projectId: ID!, $itemId: ID!, $fieldId: ID!, $optionId: String!) {
+const updateFieldValueMutation = `
+mutation UpdateFieldValue($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
   updateProjectV2ItemFieldValue(input: {
     projectId: $projectId
     itemId: $itemId
     fieldId: $fieldId
-    value: { singleSelectOptionId: $optionId }
+    value: $value
   }) {
     projectV2Item { id }
   }
 }
 `
 
+const clearFieldValueMutation = `
+mutation ClearFieldValue($projectId: ID!, $itemId: ID!, $fieldId: ID!) {
+  clearProjectV2ItemFieldValue(input: {
+    projectId: $projectId
+    itemId: $itemId
+    fieldId: $fieldId
+  }) {
+    projectV2Item { id }
+  }
+}
+`
+
+// FieldValue is the input for UpdateItemField. Exactly one member should be
+// set, matching the field's data type.
+type FieldValue struct {
+	Text                 *string
+	Number               *float64
+	Date                 string // YYYY-MM-DD
+	SingleSelectOptionID string
+	IterationID          string
+}
+
+func (v FieldValue) input() map[string]any {
+	in := map[string]any{}
+	switch {
+	case v.Text != nil:
+		in["text"] = *v.Text
+	case v.Number != nil:
+		in["number"] = *v.Number
+	case v.Date != "":
+		in["date"] = v.Date
+	case v.SingleSelectOptionID != "":
+		in["singleSelectOptionId"] = v.SingleSelectOptionID
+	case v.IterationID != "":
+		in["iterationId"] = v.IterationID
+	}
+	return in
+}
+
 func (c *Client) UpdateItemStatus(projectID, itemID, fieldID, optionID string) error {
+	return c.UpdateItemField(projectID, itemID, fieldID, FieldValue{SingleSelectOptionID: optionID})
+}
+
+func (c *Client) UpdateItemField(projectID, itemID, fieldID string, value FieldValue) error {
 	variables := map[string]any{
 		"projectId": projectID,
 		"itemId":    itemID,
 		"fieldId":   fieldID,
-		"optionId":  optionID,
+		"value":     value.input(),
 	}
 	var resp struct {
 		UpdateProjectV2ItemFieldValue struct {
@@ -29,8 +72,28 @@ func (c *Client) UpdateItemStatus(projectID, itemID, fieldID, optionID string) e
 			} `json:"projectV2Item"`
 		} `json:"updateProjectV2ItemFieldValue"`
 	}
-	if err := c.gql.Do(updateSingleSelectMutation, variables, &resp); err != nil {
-		return fmt.Errorf("update item status: %w", err)
+	if err := c.gql.Do(updateFieldValueMutation, variables, &resp); err != nil {
+		return fmt.Errorf("update item field: %w", err)
+	}
+	return nil
+}
+
+// ClearItemField removes the item's value for a field.
+func (c *Client) ClearItemField(projectID, itemID, fieldID string) error {
+	variables := map[string]any{
+		"projectId": projectID,
+		"itemId":    itemID,
+		"fieldId":   fieldID,
+	}
+	var resp struct {
+		ClearProjectV2ItemFieldValue struct {
+			ProjectV2Item struct {
+				ID string `json:"id"`
+			} `json:"projectV2Item"`
+		} `json:"clearProjectV2ItemFieldValue"`
+	}
+	if err := c.gql.Do(clearFieldValueMutation, variables, &resp); err != nil {
+		return fmt.Errorf("clear item field: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,122 @@
+package gh
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultConcurrency bounds the requests FetchPartitions keeps in
+	// flight. GitHub's secondary rate limits frown on much more than this.
+	DefaultConcurrency = 4
+	// MinPartitionedItems is the board size from which partitioned loading
+	// pays off; below it a couple of sequential pages are just as fast.
+	MinPartitionedItems = 300
+)
+
+// StatusPartitions splits a project's items into disjoint server-side
+// filters, one per Status option plus the items without a Status, so their
+// pages can be fetched in parallel instead of following one cursor chain.
+// Items on options that were deleted match none of them; callers compare the
+// result against the total and fall back to sequential paging. The filter
+// syntax has no way to quote a '"', so an option name with one means no
+// partitions at all rather than a filter matching the wrong items.
+func StatusPartitions(status SingleSelectField) []string {
+	if status.ID == "" || len(status.Options) == 0 {
+		return nil
+	}
+	for _, opt := range status.Options {
+		if strings.Contains(opt.Name, `"`) {
+			return nil
+		}
+	}
+	key := strings.ToLower(strings.ReplaceAll(status.Name, " ", "-"))
+	if key == "" {
+		key = "status"
+	}
+	filters := make([]string, 0, len(status.Options)+1)
+	for _, opt := range status.Options {
+		filters = append(filters, fmt.Sprintf(`%s:"%s"`, key, opt.Name))
+	}
+	return append(filters, "no:"+key)
+}
+
+// PartitionPage is one page delivered by FetchPartitions. A partition that
+// fails sends a single page with Err set and stops.
+type PartitionPage struct {
+	Filter string
+	Items  []Item
+	Err    error
+}
+
+// FetchPartitions pages every filter concurrently, with at most concurrency
+// requests in flight, and delivers pages on the returned channel as they
+// arrive. The channel is closed when every partition is done or ctx is
+// cancelled.
+func (c *Client) FetchPartitions(ctx context.Context, projectID, statusFieldID string, filters []string, concurrency int) <-chan PartitionPage {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	out := make(chan PartitionPage)
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	send := func(p PartitionPage) bool {
+		select {
+		case out <- p:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for _, filter := range filters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cursor := ""
+			for {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return
+				}
+				page, err := c.fetchFilteredPage(projectID, statusFieldID, filter, cursor)
+				<-sem
+				if err != nil {
+					send(PartitionPage{Filter: filter, Err: fmt.Errorf("fetch %s: %w", filter, err)})
+					return
+				}
+				if !send(PartitionPage{Filter: filter, Items: page.Items}) || page.NextCursor == "" {
+					return
+				}
+				cursor = page.NextCursor
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// loadPartitions adds every item the Status partitions return to project,
+// skipping ids in seen. It reports whether the board is now complete; errors
+// are swallowed because the caller falls back to sequential paging anyway.
+func (c *Client) loadPartitions(project *Project, seen map[string]struct{}, total int) bool {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for page := range c.FetchPartitions(ctx, project.ID, project.Status.ID, StatusPartitions(project.Status), DefaultConcurrency) {
+		for _, it := range page.Items {
+			if _, dup := seen[it.ID]; !dup {
+				seen[it.ID] = struct{}{}
+				project.Items = append(project.Items, it)
+			}
+		}
+	}
+	return len(project.Items) >= total
+}
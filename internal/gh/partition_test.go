@@ -0,0 +1,20 @@
+package gh
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStatusPartitions(t *testing.T) {
+	t.Parallel()
+	status := SingleSelectField{ID: "F", Name: "Review Stage", Options: []SingleSelectOption{{ID: "a", Name: "In Review"}, {ID: "b", Name: "Done"}}}
+	want := []string{`review-stage:"In Review"`, `review-stage:"Done"`, "no:review-stage"}
+	if got := StatusPartitions(status); !slices.Equal(got, want) {
+		t.Errorf("StatusPartitions = %q, want %q", got, want)
+	}
+
+	status.Options[1].Name = `"Done" for real`
+	if got := StatusPartitions(status); got != nil {
+		t.Errorf("with a quote in an option name: StatusPartitions = %q, want none", got)
+	}
+}
@@ -3,6 +3,8 @@ package gh
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 )
 
 // ErrProjectNotFound is returned when a search yields no project whose title
@@ -30,7 +32,7 @@ query ListProjects($login: String!, $first: Int!, $after: String) {
 
 const bootstrapByTitleQuery = `
 query BootstrapByTitle($login: String!, $query: String!) {
-  %s(login: $login) {
+  %[1]s(login: $login) {
     projectsV2(query: $query, first: 5) {
       nodes {
         id
@@ -46,6 +48,8 @@ query BootstrapByTitle($login: String!, $query: String!) {
               options {
                 id
                 name
+                color
+                description
               }
             }
           }
@@ -57,58 +61,7 @@ query BootstrapByTitle($login: String!, $query: String!) {
             endCursor
           }
           nodes {
-            id
-            fieldValues(first: 30) {
-              nodes {
-                __typename
-                ... on ProjectV2ItemFieldSingleSelectValue {
-                  optionId
-                  field {
-                    ... on ProjectV2SingleSelectField {
-                      id
-                      name
-                    }
-                  }
-                }
-              }
-            }
-            content {
-              __typename
-              ... on Issue {
-                id
-                number
-                title
-                body
-                url
-                assignees(first: 10) {
-                  nodes { login }
-                }
-                labels(first: 10) {
-                  nodes { name }
-                }
-              }
-              ... on PullRequest {
-                id
-                number
-                title
-                body
-                url
-                assignees(first: 10) {
-                  nodes { login }
-                }
-                labels(first: 10) {
-                  nodes { name }
-                }
-              }
-              ... on DraftIssue {
-                id
-                title
-                body
-                assignees(first: 10) {
-                  nodes { login }
-                }
-              }
-            }
+%[2]s
           }
         }
       }
@@ -119,7 +72,7 @@ query BootstrapByTitle($login: String!, $query: String!) {
 
 const projectByNumberQuery = `
 query ProjectByNumber($login: String!, $number: Int!) {
-  %s(login: $login) {
+  %[1]s(login: $login) {
     projectV2(number: $number) {
       id
       title
@@ -134,68 +87,20 @@ query ProjectByNumber($login: String!, $number: Int!) {
             options {
               id
               name
+              color
+              description
             }
           }
         }
       }
       items(first: 100) {
+        totalCount
         pageInfo {
           hasNextPage
           endCursor
         }
         nodes {
-          id
-          fieldValues(first: 30) {
-            nodes {
-              __typename
-              ... on ProjectV2ItemFieldSingleSelectValue {
-                optionId
-                field {
-                  ... on ProjectV2SingleSelectField {
-                    id
-                    name
-                  }
-                }
-              }
-            }
-          }
-          content {
-            __typename
-            ... on Issue {
-              id
-              number
-              title
-              body
-              url
-              assignees(first: 10) {
-                nodes { login }
-              }
-              labels(first: 10) {
-                nodes { name }
-              }
-            }
-            ... on PullRequest {
-              id
-              number
-              title
-              body
-              url
-              assignees(first: 10) {
-                nodes { login }
-              }
-              labels(first: 10) {
-                nodes { name }
-              }
-            }
-            ... on DraftIssue {
-              id
-              title
-              body
-              assignees(first: 10) {
-                nodes { login }
-              }
-            }
-          }
+%[2]s
         }
       }
     }
@@ -214,58 +119,7 @@ query ItemsPage($projectId: ID!, $cursor: String) {
           endCursor
         }
         nodes {
-          id
-          fieldValues(first: 30) {
-            nodes {
-              __typename
-              ... on ProjectV2ItemFieldSingleSelectValue {
-                optionId
-                field {
-                  ... on ProjectV2SingleSelectField {
-                    id
-                    name
-                  }
-                }
-              }
-            }
-          }
-          content {
-            __typename
-            ... on Issue {
-              id
-              number
-              title
-              body
-              url
-              assignees(first: 10) {
-                nodes { login }
-              }
-              labels(first: 10) {
-                nodes { name }
-              }
-            }
-            ... on PullRequest {
-              id
-              number
-              title
-              body
-              url
-              assignees(first: 10) {
-                nodes { login }
-              }
-              labels(first: 10) {
-                nodes { name }
-              }
-            }
-            ... on DraftIssue {
-              id
-              title
-              body
-              assignees(first: 10) {
-                nodes { login }
-              }
-            }
-          }
+%s
         }
       }
     }
@@ -289,27 +143,56 @@ type rawLabelsConn struct {
 }
 
 type rawContent struct {
-	Typename  string           `json:"__typename"`
-	ID        string           `json:"id"`
-	Number    int              `json:"number"`
-	Title     string           `json:"title"`
-	Body      string           `json:"body"`
-	URL       string           `json:"url"`
+	Typename   string `json:"__typename"`
+	ID         string `json:"id"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	URL        string `json:"url"`
+	Repository *struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
 	Assignees rawAssigneesConn `json:"assignees"`
 	Labels    rawLabelsConn    `json:"labels"`
 }
 
 type rawFieldValue struct {
-	Typename string `json:"__typename"`
-	OptionID string `json:"optionId"`
+	Typename string   `json:"__typename"`
+	OptionID string   `json:"optionId"`
+	Name     string   `json:"name"`   // single-select option name
+	Text     string   `json:"text"`   // text value
+	Number   *float64 `json:"number"` // number value
+	Date     string   `json:"date"`   // date value (YYYY-MM-DD)
+	Title    string   `json:"title"`  // iteration title
 	Field    struct {
 		ID   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"field"`
 }
 
+// display renders a field value the way the GitHub web UI shows it.
+func (fv rawFieldValue) display() (string, bool) {
+	switch fv.Typename {
+	case "ProjectV2ItemFieldSingleSelectValue":
+		return fv.Name, true
+	case "ProjectV2ItemFieldTextValue":
+		return fv.Text, true
+	case "ProjectV2ItemFieldNumberValue":
+		if fv.Number == nil {
+			return "", false
+		}
+		return strconv.FormatFloat(*fv.Number, 'f', -1, 64), true
+	case "ProjectV2ItemFieldDateValue":
+		return fv.Date, true
+	case "ProjectV2ItemFieldIterationValue":
+		return fv.Title, true
+	}
+	return "", false
+}
+
 type rawItemNode struct {
-	ID          string `json:"id"`
+	ID          string    `json:"id"`
+	UpdatedAt   time.Time `json:"updatedAt"`
 	FieldValues struct {
 		Nodes []rawFieldValue `json:"nodes"`
 	} `json:"fieldValues"`
@@ -344,25 +227,41 @@ type rawProjectNode struct {
 }
 
 func extractStatus(fields []rawFieldNode) SingleSelectField {
+	for _, f := range extractSingleSelectFields(fields) {
+		if f.Name == "Status" {
+			return f
+		}
+	}
+	return SingleSelectField{}
+}
+
+func extractSingleSelectFields(fields []rawFieldNode) []SingleSelectField {
+	var out []SingleSelectField
 	for _, f := range fields {
-		if f.Typename == "ProjectV2SingleSelectField" && f.Name == "Status" {
-			return SingleSelectField{
+		if f.Typename == "ProjectV2SingleSelectField" {
+			out = append(out, SingleSelectField{
 				ID:      f.ID,
 				Name:    f.Name,
 				Options: f.Options,
-			}
+			})
 		}
 	}
-	return SingleSelectField{}
+	return out
 }
 
 func decodeItem(n rawItemNode, statusFieldID string) Item {
-	item := Item{ID: n.ID}
+	item := Item{ID: n.ID, UpdatedAt: n.UpdatedAt}
 	for _, fv := range n.FieldValues.Nodes {
 		if fv.Typename == "ProjectV2ItemFieldSingleSelectValue" &&
 			fv.Field.ID == statusFieldID {
 			item.StatusOptionID = fv.OptionID
 		}
+		if v, ok := fv.display(); ok && fv.Field.Name != "" {
+			if item.Fields == nil {
+				item.Fields = make(map[string]string)
+			}
+			item.Fields[fv.Field.Name] = v
+		}
 	}
 	if n.Content != nil {
 		item.ContentType = ItemContentType(n.Content.Typename)
@@ -370,6 +269,9 @@ func decodeItem(n rawItemNode, statusFieldID string) Item {
 		item.Body = n.Content.Body
 		item.URL = n.Content.URL
 		item.Number = n.Content.Number
+		if n.Content.Repository != nil {
+			item.Repository = n.Content.Repository.NameWithOwner
+		}
 		for _, a := range n.Content.Assignees.Nodes {
 			item.Assignees = append(item.Assignees, a.Login)
 		}
@@ -390,6 +292,7 @@ func projectFromRaw(raw rawProjectNode) (*Project, string, int) {
 		Number: raw.Number,
 		URL:    raw.URL,
 		Status: extractStatus(raw.Fields.Nodes),
+		Fields: extractSingleSelectFields(raw.Fields.Nodes),
 	}
 	for _, n := range raw.Items.Nodes {
 		project.Items = append(project.Items, decodeItem(n, project.Status.ID))
@@ -440,7 +343,7 @@ func (c *Client) ListProjects() ([]ProjectSummary, error) {
 		}
 
 		var resp ownerWrap
-		if err := c.gql.Do(query, variables, &resp); err != nil {
+		if err := c.query(query, variables, &resp); err != nil {
 			return nil, fmt.Errorf("list projectsV2: %w", err)
 		}
 
@@ -460,10 +363,12 @@ func (c *Client) ListProjects() ([]ProjectSummary, error) {
 
 		for _, n := range page.Nodes {
 			projects = append(projects, ProjectSummary{
-				ID:     n.ID,
-				Number: n.Number,
-				Title:  n.Title,
-				URL:    n.URL,
+				ID:         n.ID,
+				Number:     n.Number,
+				Title:      n.Title,
+				URL:        n.URL,
+				OwnerType:  c.ClientType,
+				OwnerLogin: c.Login,
 			})
 		}
 
@@ -493,14 +398,14 @@ func (c *Client) bootstrapByTitle(title string) (*BootstrapResult, error) {
 		} `json:"organization,omitempty"`
 	}
 
-	query := fmt.Sprintf(bootstrapByTitleQuery, c.ownerSelector())
+	query := fmt.Sprintf(bootstrapByTitleQuery, c.ownerSelector(), c.itemFields())
 	variables := map[string]any{
 		"login": c.Login,
 		"query": title,
 	}
 
 	var resp ownerWrap
-	if err := c.gql.Do(query, variables, &resp); err != nil {
+	if err := c.query(query, variables, &resp); err != nil {
 		return nil, fmt.Errorf("bootstrap by title: %w", err)
 	}
 
@@ -551,14 +456,14 @@ func (c *Client) bootstrapByNumber(number int) (*BootstrapResult, error) {
 		} `json:"organization,omitempty"`
 	}
 
-	query := fmt.Sprintf(projectByNumberQuery, c.ownerSelector())
+	query := fmt.Sprintf(projectByNumberQuery, c.ownerSelector(), c.itemFields())
 	variables := map[string]any{
 		"login":  c.Login,
 		"number": number,
 	}
 
 	var resp ownerWrap
-	if err := c.gql.Do(query, variables, &resp); err != nil {
+	if err := c.query(query, variables, &resp); err != nil {
 		return nil, fmt.Errorf("bootstrap by number: %w", err)
 	}
 
@@ -596,7 +501,7 @@ func (c *Client) FetchItemsPage(projectID, statusFieldID, cursor string) (*Items
 			Items rawItemsConn `json:"items"`
 		} `json:"node"`
 	}
-	if err := c.gql.Do(itemsPageQuery, variables, &resp); err != nil {
+	if err := c.query(fmt.Sprintf(itemsPageQuery, c.itemFields()), variables, &resp); err != nil {
 		return nil, fmt.Errorf("fetch items page: %w", err)
 	}
 
@@ -609,3 +514,39 @@ func (c *Client) FetchItemsPage(projectID, statusFieldID, cursor string) (*Items
 	}
 	return page, nil
 }
+
+// LoadProject bootstraps the project selected by spec and follows every items
+// page, returning the complete board. Used by the non-interactive commands;
+// the TUI pages incrementally instead. Large boards are fetched as parallel
+// Status partitions first; sequential paging picks up anything they missed.
+func (c *Client) LoadProject(spec ProjectSpec) (*Project, error) {
+	res, err := c.BootstrapBySpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	project := res.Project
+	if res.NextCursor == "" {
+		return project, nil
+	}
+	seen := make(map[string]struct{}, res.TotalItems)
+	for _, it := range project.Items {
+		seen[it.ID] = struct{}{}
+	}
+	if res.TotalItems >= MinPartitionedItems && c.loadPartitions(project, seen, res.TotalItems) {
+		return project, nil
+	}
+	for cursor := res.NextCursor; cursor != ""; {
+		page, err := c.FetchItemsPage(project.ID, project.Status.ID, cursor)
+		if err != nil {
+			return nil, err
+		}
+		for _, it := range page.Items {
+			if _, dup := seen[it.ID]; !dup {
+				seen[it.ID] = struct{}{}
+				project.Items = append(project.Items, it)
+			}
+		}
+		cursor = page.NextCursor
+	}
+	return project, nil
+}
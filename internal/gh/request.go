@@ -0,0 +1,223 @@
+package gh
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	// requestTimeout bounds a single GraphQL round-trip so a stalled
+	// connection surfaces as a retryable timeout instead of hanging.
+	requestTimeout = 60 * time.Second
+	maxAttempts    = 5
+	baseBackoff    = time.Second
+	maxBackoff     = 30 * time.Second
+	// secondaryBackoff is GitHub's advice for secondary rate limits that
+	// come without a Retry-After header.
+	secondaryBackoff = time.Minute
+	// maxRateLimitWait is the longest we sleep for the primary budget to
+	// reset before giving up and reporting the error.
+	maxRateLimitWait = 5 * time.Minute
+)
+
+const rateLimitSelection = "\n  rateLimit { limit remaining resetAt cost }"
+
+// query runs a read-only GraphQL query. It adds the rateLimit selection when
+// the query lacks one, records the reported budget, and retries transient
+// failures (5xx, timeouts, dropped connections, rate limits) with
+// exponential backoff. Mutations must not go through here: they are not
+// idempotent.
+func (c *Client) query(query string, variables map[string]any, resp any) error {
+	query = withRateLimit(query)
+	var data json.RawMessage
+	if err := c.retry(func() error { return c.gql.Do(query, variables, &data) }); err != nil {
+		return err
+	}
+	var rl struct {
+		RateLimit *RateLimit `json:"rateLimit"`
+	}
+	if json.Unmarshal(data, &rl) == nil && rl.RateLimit != nil {
+		c.setRateLimit(*rl.RateLimit)
+	}
+	return json.Unmarshal(data, resp)
+}
+
+// retry runs an idempotent request until it succeeds, fails for good or
+// runs out of attempts, waiting as retryDelay says in between.
+func (c *Client) retry(do func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := do()
+		if err == nil {
+			return nil
+		}
+		delay, ok := c.retryDelay(err, attempt, time.Now())
+		if !ok || attempt >= maxAttempts {
+			return err
+		}
+		c.wait(delay)
+	}
+}
+
+// withRateLimit adds a top-level rateLimit selection to a query operation.
+func withRateLimit(query string) string {
+	if strings.Contains(query, "rateLimit") {
+		return query
+	}
+	op := strings.Index(query, "query ")
+	if op < 0 {
+		return query
+	}
+	brace := strings.Index(query[op:], "{")
+	if brace < 0 {
+		return query
+	}
+	at := op + brace + 1
+	return query[:at] + rateLimitSelection + query[at:]
+}
+
+// retryDelay decides whether err is worth another attempt and how long to
+// wait first.
+func (c *Client) retryDelay(err error, attempt int, now time.Time) (time.Duration, bool) {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode >= 500:
+			return backoff(attempt), true
+		case httpErr.StatusCode == http.StatusTooManyRequests, httpErr.StatusCode == http.StatusForbidden:
+			if d, ok := retryAfter(httpErr.Headers, now); ok {
+				return d, true
+			}
+			if httpErr.StatusCode == http.StatusTooManyRequests || strings.Contains(strings.ToLower(httpErr.Message), "rate limit") {
+				return max(backoff(attempt), secondaryBackoff), true
+			}
+		}
+		return 0, false
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, e := range gqlErr.Errors {
+			if e.Type != "RATE_LIMITED" {
+				continue
+			}
+			// The budget from the last query is the best guess; failing
+			// that, the headers the error came with, and then backoff.
+			if reset := c.RateLimit().ResetAt; !reset.IsZero() && reset.After(now) {
+				if wait := reset.Sub(now); wait <= maxRateLimitWait {
+					return wait, true
+				}
+				return 0, false
+			}
+			if d, ok := retryAfter(c.limitHeader(), now); ok {
+				return d, true
+			}
+			return max(backoff(attempt), secondaryBackoff), true
+		}
+		return 0, false
+	}
+
+	if transientNetError(err) {
+		return backoff(attempt), true
+	}
+	return 0, false
+}
+
+// transientNetError reports whether err is a timeout or a dropped
+// connection, which a retry may well get past.
+func transientNetError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// backoff doubles from baseBackoff per attempt, capped, with ±25% jitter so
+// parallel partitions do not retry in lockstep.
+func backoff(attempt int) time.Duration {
+	d := baseBackoff << (attempt - 1)
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	jitter := time.Duration(rand.Int64N(int64(d)/2)) - d/4
+	return d + jitter
+}
+
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if wait := time.Unix(reset, 0).Sub(now); wait > 0 && wait <= maxRateLimitWait {
+				return wait, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// headerWatcher keeps the rate-limit headers of every response for
+// retryDelay: a RATE_LIMITED GraphQL error arrives with status 200, so the
+// error itself has none.
+type headerWatcher struct {
+	c    *Client
+	next http.RoundTripper
+}
+
+func (w headerWatcher) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := w.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	h := http.Header{}
+	for _, k := range []string{"Retry-After", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+		if v := resp.Header.Values(k); len(v) > 0 {
+			h[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+	w.c.mu.Lock()
+	w.c.limitHdr = h
+	w.c.mu.Unlock()
+	return resp, nil
+}
+
+func (c *Client) limitHeader() http.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limitHdr
+}
+
+func (c *Client) wait(d time.Duration) {
+	if c.sleep != nil {
+		c.sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+// RateLimit returns the GraphQL budget reported by the most recent query.
+// The zero value means no query has completed yet.
+func (c *Client) RateLimit() RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rateLimit
+}
+
+func (c *Client) setRateLimit(rl RateLimit) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = rl
+}
@@ -0,0 +1,193 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	t.Parallel()
+	got := withRateLimit("\nquery ItemsPage($query: String!) {\n  node(id: $id) { id }\n}\n")
+	want := "\nquery ItemsPage($query: String!) {" + rateLimitSelection + "\n  node(id: $id) { id }\n}\n"
+	if got != want {
+		t.Fatalf("withRateLimit =\n%s\nwant\n%s", got, want)
+	}
+	if q := "query X { rateLimit { cost } }"; withRateLimit(q) != q {
+		t.Fatal("a query that already selects rateLimit must be left alone")
+	}
+	if m := "mutation M { x }"; withRateLimit(m) != m {
+		t.Fatal("mutations must be left alone")
+	}
+}
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := &Client{rateLimit: RateLimit{Limit: 5000, ResetAt: now.Add(2 * time.Minute)}}
+
+	cases := []struct {
+		name      string
+		err       error
+		wantRetry bool
+		wantDelay time.Duration // 0: only check that some backoff was chosen
+	}{
+		{"bad gateway", &api.HTTPError{StatusCode: 502}, true, 0},
+		{"timeout", fmt.Errorf("wrapped: %w", timeoutErr{}), true, 0},
+		{"retry-after", &api.HTTPError{StatusCode: 403, Headers: http.Header{"Retry-After": {"7"}}}, true, 7 * time.Second},
+		{"secondary limit without header", &api.HTTPError{StatusCode: 403, Message: "You have exceeded a secondary rate limit"}, true, secondaryBackoff},
+		{"plain forbidden", &api.HTTPError{StatusCode: 403, Message: "Resource not accessible"}, false, 0},
+		{"not found", &api.HTTPError{StatusCode: 404}, false, 0},
+		{"rate limited waits for reset", &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "RATE_LIMITED"}}}, true, 2 * time.Minute},
+		{"schema error", &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "NOT_FOUND"}}}, false, 0},
+		{"other", errors.New("boom"), false, 0},
+	}
+	for _, tc := range cases {
+		d, ok := c.retryDelay(tc.err, 1, now)
+		if ok != tc.wantRetry {
+			t.Errorf("%s: retry = %v, want %v", tc.name, ok, tc.wantRetry)
+			continue
+		}
+		if tc.wantDelay != 0 && d != tc.wantDelay {
+			t.Errorf("%s: delay = %s, want %s", tc.name, d, tc.wantDelay)
+		}
+		if ok && d <= 0 {
+			t.Errorf("%s: delay = %s, want > 0", tc.name, d)
+		}
+	}
+}
+
+func TestRetryDelay_RateLimitedWithoutKnownReset(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limited := &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "RATE_LIMITED"}}}
+
+	c := &Client{limitHdr: http.Header{"Retry-After": {"20"}}}
+	if d, ok := c.retryDelay(limited, 1, now); !ok || d != 20*time.Second {
+		t.Errorf("with Retry-After: delay = %s, %v; want 20s", d, ok)
+	}
+	c = &Client{limitHdr: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(now.Add(time.Minute).Unix(), 10)}}}
+	if d, ok := c.retryDelay(limited, 1, now); !ok || d != time.Minute {
+		t.Errorf("with X-RateLimit-Reset: delay = %s, %v; want 1m", d, ok)
+	}
+	c = &Client{rateLimit: RateLimit{ResetAt: now.Add(-time.Minute)}}
+	if d, ok := c.retryDelay(limited, 1, now); !ok || d < secondaryBackoff {
+		t.Errorf("with a stale reset and no headers: delay = %s, %v; want a backoff of at least %s", d, ok, secondaryBackoff)
+	}
+}
+
+func TestQuery_RateLimitedWaitsForHeaderReset(t *testing.T) {
+	t.Parallel()
+	limited := jsonResponse(200, `{"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`)
+	limited.Header.Set("X-RateLimit-Remaining", "0")
+	limited.Header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(90*time.Second).Unix(), 10))
+	transport := &scriptedTransport{responses: []*http.Response{
+		limited,
+		jsonResponse(200, `{"data":{"node":{"id":"P_1"}}}`),
+	}}
+	var slept []time.Duration
+	c := &Client{sleep: func(d time.Duration) { slept = append(slept, d) }}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: headerWatcher{c: c, next: transport}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.gql = gql
+
+	var resp struct{}
+	if err := c.query("query Q {\n  node(id: \"P_1\") { id }\n}", nil, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(slept) != 1 || slept[0] < 80*time.Second || slept[0] > 90*time.Second {
+		t.Fatalf("slept %v, want one wait until the header's reset", slept)
+	}
+}
+
+func TestBackoffGrowsAndCaps(t *testing.T) {
+	t.Parallel()
+	for attempt, base := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		d := backoff(attempt + 1)
+		if d < base*3/4 || d > base*5/4 {
+			t.Errorf("backoff(%d) = %s, want %s ±25%%", attempt+1, d, base)
+		}
+	}
+	if d := backoff(40); d > maxBackoff*5/4 {
+		t.Errorf("backoff(40) = %s, want capped near %s", d, maxBackoff)
+	}
+}
+
+func TestQuery_RetriesAndRecordsRateLimit(t *testing.T) {
+	t.Parallel()
+	transport := &scriptedTransport{responses: []*http.Response{
+		jsonResponse(502, `{"message":"Bad Gateway"}`),
+		jsonResponse(200, `{"data":{"rateLimit":{"limit":5000,"remaining":4321,"resetAt":"2026-01-01T13:00:00Z","cost":1},"node":{"id":"P_1"}}}`),
+	}}
+	gql, err := api.NewGraphQLClient(api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slept []time.Duration
+	c := &Client{gql: gql, sleep: func(d time.Duration) { slept = append(slept, d) }}
+
+	var resp struct {
+		Node struct {
+			ID string `json:"id"`
+		} `json:"node"`
+	}
+	if err := c.query("query Q($id: ID!) {\n  node(id: $id) { id }\n}", map[string]any{"id": "P_1"}, &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Node.ID != "P_1" {
+		t.Fatalf("decoded node id = %q", resp.Node.ID)
+	}
+	if len(slept) != 1 || len(transport.requests) != 2 {
+		t.Fatalf("slept %v over %d requests, want one retry", slept, len(transport.requests))
+	}
+	if transport.requests[0] != transport.requests[1] {
+		t.Fatal("a retry must resend the identical request (same cursor)")
+	}
+	if rl := c.RateLimit(); rl.Remaining != 4321 || rl.Cost != 1 {
+		t.Fatalf("RateLimit = %+v", rl)
+	}
+}
+
+func TestFetchDiff_RetriesAndKeepsGoingWithoutIt(t *testing.T) {
+	t.Parallel()
+	diff := &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("diff --git a/x b/x\n"))}
+	transport := &scriptedTransport{responses: []*http.Response{
+		jsonResponse(502, `{"message":"Bad Gateway"}`),
+		diff,
+		jsonResponse(406, `{"message":"Sorry, the diff exceeded the maximum number of files (300)."}`),
+	}}
+	opts := api.ClientOptions{Host: "github.com", AuthToken: "t", Transport: transport}
+	c := &Client{opts: opts, sleep: func(time.Duration) {}}
+
+	ctx := &ItemContext{RepoNameOwner: "acme/app", Number: 7}
+	if err := c.fetchDiff(ctx, 0); err != nil || ctx.Diff != "diff --git a/x b/x\n" {
+		t.Fatalf("fetchDiff = %v, diff %q; want the diff after one retry", err, ctx.Diff)
+	}
+	if got := len(transport.requests); got != 2 {
+		t.Fatalf("requests = %d, want 2", got)
+	}
+
+	// A 406 is not retried; fetchExtendedContext keeps it as DiffError.
+	ctx = &ItemContext{RepoNameOwner: "acme/app", Number: 7}
+	if err := c.fetchDiff(ctx, 0); err == nil {
+		t.Fatal("fetchDiff of a too-large diff: want an error")
+	}
+	if got := len(transport.requests); got != 3 {
+		t.Fatalf("requests = %d, want the 406 not retried", got)
+	}
+}
@@ -0,0 +1,216 @@
+package gh
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ItemSelection decides which parts of every project item the board queries
+// fetch. Bodies and the full fieldValues connection dominate both payload
+// size and GraphQL cost on big projects, so views that don't show them leave
+// them out. The item id, type, title, number, URL and repository are always
+// fetched, as is the Status value that places cards in columns.
+type ItemSelection struct {
+	// Body fetches the issue/PR/draft body. Without it, load bodies on
+	// demand with FetchItemBody.
+	Body bool
+	// AllFields fetches every field value. Otherwise only Status and the
+	// fields named in Fields are fetched, each with fieldValueByName.
+	AllFields bool
+	Fields    []string
+	Assignees bool
+	Labels    bool
+}
+
+// FullItemSelection fetches everything; the export and scripting commands
+// need it.
+var FullItemSelection = ItemSelection{Body: true, AllFields: true, Assignees: true, Labels: true}
+
+// BoardItemSelection is what the interactive board shows: card titles and
+// badges plus assignees and labels for the detail pane. Bodies are loaded
+// lazily for the selected card. fields adds extra field values by name,
+// e.g. the grouping field.
+func BoardItemSelection(fields ...string) ItemSelection {
+	return ItemSelection{Assignees: true, Labels: true, Fields: fields}
+}
+
+// SetItemSelection changes what subsequent board queries fetch per item.
+// The default is FullItemSelection.
+func (c *Client) SetItemSelection(sel ItemSelection) {
+	c.selection = &sel
+}
+
+func (c *Client) itemSelection() ItemSelection {
+	if c.selection == nil {
+		return FullItemSelection
+	}
+	return *c.selection
+}
+
+// itemFields is the item node selection for the board queries' "%s" slot.
+func (c *Client) itemFields() string {
+	return indent(c.itemSelection().build(), 10)
+}
+
+// fieldAliasPrefix names the fieldValueByName aliases (fv0, fv1, ...);
+// rawItemNode.UnmarshalJSON folds them back into FieldValues.
+const fieldAliasPrefix = "fv"
+
+const fieldValueSelection = `__typename
+... on ProjectV2ItemFieldSingleSelectValue {
+  optionId
+  name
+  field {
+    ... on ProjectV2SingleSelectField {
+      id
+      name
+    }
+  }
+}
+... on ProjectV2ItemFieldTextValue {
+  text
+  field { ... on ProjectV2FieldCommon { name } }
+}
+... on ProjectV2ItemFieldNumberValue {
+  number
+  field { ... on ProjectV2FieldCommon { name } }
+}
+... on ProjectV2ItemFieldDateValue {
+  date
+  field { ... on ProjectV2FieldCommon { name } }
+}
+... on ProjectV2ItemFieldIterationValue {
+  title
+  field { ... on ProjectV2FieldCommon { name } }
+}`
+
+// fieldNames is Status plus the requested fields, deduplicated
+// case-insensitively, in a stable order.
+func (s ItemSelection) fieldNames() []string {
+	seen := map[string]bool{"status": true}
+	names := []string{"Status"}
+	for _, f := range s.Fields {
+		if f == "" || seen[strings.ToLower(f)] {
+			continue
+		}
+		seen[strings.ToLower(f)] = true
+		names = append(names, f)
+	}
+	return names
+}
+
+// build returns the selection set for one ProjectV2Item node, without the
+// surrounding braces.
+func (s ItemSelection) build() string {
+	var b strings.Builder
+	b.WriteString("id\nupdatedAt\n")
+
+	if s.AllFields {
+		fmt.Fprintf(&b, "fieldValues(first: 30) {\n  nodes {\n%s\n  }\n}\n", indent(fieldValueSelection, 4))
+	} else {
+		for i, name := range s.fieldNames() {
+			fmt.Fprintf(&b, "%s%d: fieldValueByName(name: %s) {\n%s\n}\n", fieldAliasPrefix, i, strconv.Quote(name), indent(fieldValueSelection, 2))
+		}
+	}
+
+	common := "id\nnumber\ntitle\n"
+	if s.Body {
+		common += "body\n"
+	}
+	common += "url\nrepository { nameWithOwner }\n"
+	people := ""
+	if s.Assignees {
+		people = "assignees(first: 10) {\n  nodes { login }\n}\n"
+	}
+	labels := ""
+	if s.Labels {
+		labels = "labels(first: 10) {\n  nodes { name }\n}\n"
+	}
+	draft := "id\ntitle\n"
+	if s.Body {
+		draft += "body\n"
+	}
+
+	b.WriteString("content {\n  __typename\n")
+	for _, typ := range []string{"Issue", "PullRequest"} {
+		fmt.Fprintf(&b, "  ... on %s {\n%s\n  }\n", typ, indent(common+people+labels, 4))
+	}
+	fmt.Fprintf(&b, "  ... on DraftIssue {\n%s\n  }\n", indent(draft+people, 4))
+	b.WriteString("}")
+	return b.String()
+}
+
+func indent(s string, n int) string {
+	pad := strings.Repeat(" ", n)
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = pad + l
+	}
+	return strings.Join(lines, "\n")
+}
+
+// UnmarshalJSON accepts both shapes the builder produces: the fieldValues
+// connection, or one fieldValueByName alias per requested field.
+func (n *rawItemNode) UnmarshalJSON(data []byte) error {
+	type plain rawItemNode
+	if err := json.Unmarshal(data, (*plain)(n)); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		if strings.HasPrefix(k, fieldAliasPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var fv *rawFieldValue
+		if err := json.Unmarshal(all[k], &fv); err != nil {
+			return fmt.Errorf("decode %s: %w", k, err)
+		}
+		if fv != nil {
+			n.FieldValues.Nodes = append(n.FieldValues.Nodes, *fv)
+		}
+	}
+	return nil
+}
+
+const itemBodyQuery = `
+query ItemBody($itemId: ID!) {
+  node(id: $itemId) {
+    ... on ProjectV2Item {
+      content {
+        ... on Issue { body }
+        ... on PullRequest { body }
+        ... on DraftIssue { body }
+      }
+    }
+  }
+}
+`
+
+// FetchItemBody loads one item's body, for views that leave bodies out of
+// the board queries.
+func (c *Client) FetchItemBody(itemID string) (string, error) {
+	var resp struct {
+		Node struct {
+			Content *struct {
+				Body string `json:"body"`
+			} `json:"content"`
+		} `json:"node"`
+	}
+	if err := c.query(itemBodyQuery, map[string]any{"itemId": itemID}, &resp); err != nil {
+		return "", fmt.Errorf("fetch item body: %w", err)
+	}
+	if resp.Node.Content == nil {
+		return "", nil
+	}
+	return resp.Node.Content.Body, nil
+}
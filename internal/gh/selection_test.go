@@ -0,0 +1,89 @@
+package gh
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestItemSelection_Build(t *testing.T) {
+	t.Parallel()
+	full := FullItemSelection.build()
+	board := BoardItemSelection("Priority", "status").build()
+
+	for _, want := range []string{"body", "fieldValues(first: 30)", "assignees(first: 10)", "labels(first: 10)"} {
+		if !strings.Contains(full, want) {
+			t.Errorf("full selection missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"body", "fieldValues("} {
+		if strings.Contains(board, unwanted) {
+			t.Errorf("board selection should not fetch %q:\n%s", unwanted, board)
+		}
+	}
+	// Status is always fetched, and only once however it is spelled.
+	if strings.Count(board, "fieldValueByName") != 2 ||
+		!strings.Contains(board, `fv0: fieldValueByName(name: "Status")`) ||
+		!strings.Contains(board, `fv1: fieldValueByName(name: "Priority")`) {
+		t.Errorf("unexpected field aliases:\n%s", board)
+	}
+}
+
+func TestRawItemNode_DecodesFieldAliases(t *testing.T) {
+	t.Parallel()
+	data := `{
+		"id": "PVTI_1",
+		"fv0": {"__typename": "ProjectV2ItemFieldSingleSelectValue", "optionId": "opt_todo", "name": "Todo", "field": {"id": "F_status", "name": "Status"}},
+		"fv1": null,
+		"fv2": {"__typename": "ProjectV2ItemFieldNumberValue", "number": 3, "field": {"name": "Estimate"}},
+		"content": {"__typename": "Issue", "id": "I_1", "number": 7, "title": "Fix it", "url": "u", "repository": {"nameWithOwner": "acme/app"}}
+	}`
+	var n rawItemNode
+	if err := json.Unmarshal([]byte(data), &n); err != nil {
+		t.Fatal(err)
+	}
+	got := decodeItem(n, "F_status")
+	want := Item{
+		ID: "PVTI_1", ContentType: ContentIssue, Title: "Fix it", URL: "u", Number: 7,
+		Repository: "acme/app", StatusOptionID: "opt_todo",
+		Fields: map[string]string{"Status": "Todo", "Estimate": "3"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("decoded item mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// requestedNodes adds up what GitHub's node limit charges per item for a
+// selection: every connection's first: N, and one node per
+// fieldValueByName.
+func requestedNodes(selection string) int {
+	n := strings.Count(selection, "fieldValueByName")
+	for _, m := range regexp.MustCompile(`first: (\d+)`).FindAllStringSubmatch(selection, -1) {
+		v, _ := strconv.Atoi(m[1])
+		n += v
+	}
+	return n
+}
+
+func TestItemSelection_BoardCostsLess(t *testing.T) {
+	t.Parallel()
+	full := FullItemSelection.build()
+	board := BoardItemSelection().build()
+
+	// fieldValues(first: 30), then assignees and labels on issues and PRs
+	// and assignees on drafts: 30 + 3×10 + 2×10. The board swaps the 30
+	// field values for one fieldValueByName (Status).
+	if got, want := requestedNodes(full), 80; got != want {
+		t.Errorf("full selection requests %d nodes per item, want %d", got, want)
+	}
+	if got, want := requestedNodes(board), 51; got != want {
+		t.Errorf("board selection requests %d nodes per item, want %d", got, want)
+	}
+	if len(board) >= len(full) {
+		t.Errorf("board selection is %d bytes, full %d; want it smaller", len(board), len(full))
+	}
+}
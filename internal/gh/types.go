@@ -1,15 +1,31 @@
 package gh
 
+import (
+	"strings"
+	"time"
+)
+
 type ProjectSummary struct {
 	ID     string
 	Number int
 	Title  string
 	URL    string
+	// OwnerType and OwnerLogin name the project's owner, which for projects
+	// linked to a repository may not be the repository's.
+	OwnerType  ClientType
+	OwnerLogin string
 }
 
 type SingleSelectOption struct {
 	ID   string
 	Name string
+	// Color is GitHub's option colour: GRAY, BLUE, GREEN, YELLOW, ORANGE,
+	// RED, PINK or PURPLE.
+	Color string
+
+	// Description is the option's free text from the project settings; the
+	// board reads WIP limits such as "WIP: 3" out of it.
+	Description string
 }
 
 type SingleSelectField struct {
@@ -24,9 +40,25 @@ type Project struct {
 	Number int
 	URL    string
 	Status SingleSelectField
+	// Fields lists every SingleSelect field of the project (Status
+	// included), in the order GitHub returns them.
+	Fields []SingleSelectField
 	Items  []Item
 }
 
+// Field returns the SingleSelect field with the given name (case-insensitive).
+func (p *Project) Field(name string) (SingleSelectField, bool) {
+	for _, f := range p.Fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	if strings.EqualFold(p.Status.Name, name) && p.Status.ID != "" {
+		return p.Status, true
+	}
+	return SingleSelectField{}, false
+}
+
 type ItemContentType string
 
 const (
@@ -42,9 +74,16 @@ type Item struct {
 	Body           string
 	URL            string
 	Number         int
+	Repository     string // nameWithOwner; empty for draft issues
 	Assignees      []string
 	Labels         []string
 	StatusOptionID string
+	// Fields maps a project field name to the item's value as displayed on
+	// GitHub (option name, text, number, date or iteration title). Fields
+	// without a value are absent.
+	Fields map[string]string
+	// UpdatedAt is the project item's last update, used for delta syncs.
+	UpdatedAt time.Time
 }
 
 // ProjectSpec selects a project either by exact title or by project number.
@@ -71,3 +110,12 @@ type ItemsPage struct {
 	NextCursor string
 	TotalItems int
 }
+
+// RateLimit is GitHub's GraphQL points budget as reported by a query's
+// rateLimit field. The zero value means the query did not ask for it.
+type RateLimit struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"resetAt"`
+	Cost      int       `json:"cost"` // points charged for the reporting query
+}
@@ -0,0 +1,71 @@
+package gh
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ProjectRef names a project by its owner and number, as in the URL
+// https://github.com/orgs/acme/projects/7.
+type ProjectRef struct {
+	Host      string // github.com, or a GitHub Enterprise host
+	OwnerType ClientType
+	Login     string
+	Number    int
+}
+
+// Params returns the client parameters for the project's owner, on the
+// URL's host.
+func (r ProjectRef) Params() InitParams {
+	p := OwnerParams(r.OwnerType, r.Login)
+	p.Host = r.Host
+	return p
+}
+
+// LooksLikeProjectURL tells a project URL apart from a board alias or a
+// project title.
+func LooksLikeProjectURL(s string) bool {
+	_, err := ParseProjectURL(s)
+	return err == nil
+}
+
+// ParseProjectURL reads the host, owner and number from a project URL such
+// as https://github.com/orgs/acme/projects/7 or
+// https://github.com/users/alice/projects/3/views/2. The scheme may be left
+// out; a GitHub Enterprise host is kept so the client is built for it.
+func ParseProjectURL(raw string) (ProjectRef, error) {
+	s := strings.TrimSpace(raw)
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return ProjectRef{}, fmt.Errorf("project URL %q: %w", raw, err)
+	}
+	if u.Host == "" {
+		return ProjectRef{}, fmt.Errorf("project URL %q: no host", raw)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "projects" {
+		return ProjectRef{}, fmt.Errorf("project URL %q: want https://github.com/orgs/OWNER/projects/N or /users/OWNER/projects/N", raw)
+	}
+	ref := ProjectRef{Host: strings.ToLower(u.Host)}
+	switch parts[0] {
+	case "orgs":
+		ref.OwnerType = ClientTypeOrganization
+	case "users":
+		ref.OwnerType = ClientTypeUser
+	default:
+		// github.com/OWNER/REPO/projects/N is a classic project.
+		return ProjectRef{}, fmt.Errorf("project URL %q: only Projects (orgs/… or users/…) are supported, not classic repository projects", raw)
+	}
+	ref.Login = parts[1]
+	n, err := strconv.Atoi(parts[3])
+	if err != nil || n <= 0 || ref.Login == "" {
+		return ProjectRef{}, fmt.Errorf("project URL %q: no project number", raw)
+	}
+	ref.Number = n
+	return ref, nil
+}
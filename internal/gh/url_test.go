@@ -0,0 +1,48 @@
+package gh
+
+import "testing"
+
+func TestParseProjectURL(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		in   string
+		want ProjectRef
+	}{
+		{"https://github.com/orgs/acme/projects/7", ProjectRef{"github.com", ClientTypeOrganization, "acme", 7}},
+		{"https://github.com/users/alice/projects/3/views/2", ProjectRef{"github.com", ClientTypeUser, "alice", 3}},
+		{"github.com/orgs/acme/projects/12/", ProjectRef{"github.com", ClientTypeOrganization, "acme", 12}},
+		{"https://GHE.example.com/orgs/acme/projects/7?pane=issue", ProjectRef{"ghe.example.com", ClientTypeOrganization, "acme", 7}},
+	}
+	for _, tc := range cases {
+		got, err := ParseProjectURL(tc.in)
+		if err != nil || got != tc.want {
+			t.Errorf("ParseProjectURL(%q) = %+v, %v; want %+v", tc.in, got, err, tc.want)
+		}
+	}
+
+	for _, bad := range []string{
+		"https://github.com/acme/app/projects/1", // classic
+		"https://github.com/orgs/acme/projects/",
+		"https://github.com/orgs/acme/projects/x",
+		"https://github.com/orgs/acme",
+	} {
+		if got, err := ParseProjectURL(bad); err == nil {
+			t.Errorf("ParseProjectURL(%q) = %+v, want an error", bad, got)
+		}
+	}
+}
+
+func TestLooksLikeProjectURL(t *testing.T) {
+	t.Parallel()
+	for in, want := range map[string]bool{
+		"https://github.com/orgs/acme/projects/7": true,
+		"github.com/users/alice/projects/3":       true,
+		"sprint":                                  false,
+		"notes/projects/plan":                     false,
+		"https://github.com/acme/app/projects/1":  false, // classic
+	} {
+		if got := LooksLikeProjectURL(in); got != want {
+			t.Errorf("LooksLikeProjectURL(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
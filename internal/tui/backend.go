@@ -0,0 +1,27 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// Backend is everything the board asks of GitHub: bootstrap and paging,
+// delta syncs, the Status mutation, item context for yank and the project
+// list for the switcher. *gh.Client implements it; ghfake.Backend is an
+// in-memory stand-in for tests.
+type Backend interface {
+	BootstrapBySpec(spec gh.ProjectSpec) (*gh.BootstrapResult, error)
+	FetchItemsPage(projectID, statusFieldID, cursor string) (*gh.ItemsPage, error)
+	FetchPartitions(ctx context.Context, projectID, statusFieldID string, filters []string, concurrency int) <-chan gh.PartitionPage
+	FetchItemsSince(projectID, statusFieldID string, since time.Time) (*gh.ItemsDelta, error)
+	FetchItemIDs(projectID string) (map[string]struct{}, error)
+	FetchItemBody(itemID string) (string, error)
+	FetchItemContext(itemID string, opts gh.ItemContextOptions) (*gh.ItemContext, error)
+	UpdateItemStatus(projectID, itemID, fieldID, optionID string) error
+	RateLimit() gh.RateLimit
+	ListProjects() ([]gh.ProjectSummary, error)
+}
+
+var _ Backend = (*gh.Client)(nil)
@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// collapsedColW is the width of a collapsed column: border, padding and
+// room for a three-digit count.
+const collapsedColW = 7
+
+// loadColumnPrefs reads the local column layout when p is a project the
+// model hasn't seen yet. The file is a few hundred bytes, so it is read
+// inline and the first paint already has the user's layout.
+func (m *Model) loadColumnPrefs(p *gh.Project) {
+	if p == nil || m.opts.Layouts == nil {
+		return
+	}
+	key := m.columnPrefsKey(p)
+	if key == m.colPrefsID {
+		return
+	}
+	m.colPrefsID = key
+	// An unreadable layout just means GitHub's.
+	m.colPrefs, _ = m.opts.Layouts.LoadColumns(key)
+}
+
+// columnPrefsKey names p's saved column layout. Option IDs, and the No
+// Status column's above all, only mean something within one field, so a
+// board grouped by another field keeps a layout of its own.
+func (m Model) columnPrefsKey(p *gh.Project) string {
+	if m.opts.Layout.GroupBy == "" {
+		return p.ID
+	}
+	f, err := board.GroupField(p, m.opts.Layout.GroupBy)
+	if err != nil || f.ID == p.Status.ID {
+		return p.ID
+	}
+	return p.ID + "." + f.ID
+}
+
+// strip reports whether column i is drawn collapsed. The focused column is
+// always drawn open, so the cursor never sits on cards nobody can see.
+func (m Model) strip(i int) bool {
+	return m.columns[i].collapsed && i != m.focusCol
+}
+
+// customLayout reports whether columns differ from one per Status option in
+// GitHub's order, so appended pages must rebuild them.
+func (m *Model) customLayout() bool {
+	return !m.opts.Layout.isDefault() || !m.colPrefs.Empty()
+}
+
+// arrangeColumns applies the local column layout: hidden columns go,
+// collapsed ones are marked and the rest are put in the saved order.
+func (m *Model) arrangeColumns(cols []column) []column {
+	prefs := m.colPrefs
+	if prefs.Empty() {
+		return cols
+	}
+	out := make([]column, 0, len(cols))
+	for _, c := range cols {
+		if slices.Contains(prefs.Hidden, c.optionID) {
+			continue
+		}
+		c.collapsed = slices.Contains(prefs.Collapsed, c.optionID)
+		out = append(out, c)
+	}
+	rank := func(i int) int {
+		if r := slices.Index(prefs.Order, out[i].optionID); r >= 0 {
+			return r
+		}
+		return len(prefs.Order)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return rank(i) < rank(j) })
+	return out
+}
+
+// toggleCollapse collapses the focused column to a strip showing its count,
+// or expands it again. Focus leaves a column it collapses for the nearest
+// open one; moving back onto the strip opens it up until focus moves on.
+func (m Model) toggleCollapse() (tea.Model, tea.Cmd) {
+	id := m.columns[m.focusCol].optionID
+	if i := slices.Index(m.colPrefs.Collapsed, id); i >= 0 {
+		m.colPrefs.Collapsed = slices.Delete(slices.Clone(m.colPrefs.Collapsed), i, i+1)
+		return m, m.applyColumnPrefs()
+	}
+	m.colPrefs.Collapsed = append(slices.Clip(m.colPrefs.Collapsed), id)
+	cmd := m.applyColumnPrefs()
+	if i := m.nearestOpenColumn(); i >= 0 {
+		m.focusCol = i
+	}
+	return m, cmd
+}
+
+// nearestOpenColumn is the closest column to the focused one that isn't
+// collapsed, right before left; -1 when every column is.
+func (m Model) nearestOpenColumn() int {
+	for d := 1; d < len(m.columns); d++ {
+		for _, i := range []int{m.focusCol + d, m.focusCol - d} {
+			if i >= 0 && i < len(m.columns) && !m.columns[i].collapsed {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// hideColumn takes the focused column off the board until show-columns.
+func (m Model) hideColumn() (tea.Model, tea.Cmd) {
+	if len(m.columns) == 1 {
+		m.status = "The last column can't be hidden."
+		return m, clearStatusAfter(statusLifetime)
+	}
+	col := m.columns[m.focusCol]
+	m.colPrefs.Hidden = append(slices.Clip(m.colPrefs.Hidden), col.optionID)
+	m.status = fmt.Sprintf("%s hidden.", col.name)
+	if k := m.keys.primary(ActionShowColumns); k != "" {
+		m.status = fmt.Sprintf("%s hidden · %s shows hidden columns.", col.name, k)
+	}
+	m.applyColumnPrefs()
+	return m, clearStatusAfter(statusLifetime)
+}
+
+// showColumns brings back every column hidden with hide-column.
+func (m Model) showColumns() (tea.Model, tea.Cmd) {
+	n := len(m.colPrefs.Hidden)
+	if n == 0 {
+		return m, nil
+	}
+	m.colPrefs.Hidden = nil
+	m.status = fmt.Sprintf("%d hidden column(s) shown.", n)
+	m.applyColumnPrefs()
+	return m, clearStatusAfter(statusLifetime)
+}
+
+// shiftColumn swaps the focused column with its neighbour (delta -1 or 1);
+// focus follows the column.
+func (m Model) shiftColumn(delta int) (tea.Model, tea.Cmd) {
+	j := m.focusCol + delta
+	if j < 0 || j >= len(m.columns) {
+		return m, nil
+	}
+	order := make([]string, 0, len(m.columns)+len(m.colPrefs.Order))
+	for _, c := range m.columns {
+		order = append(order, c.optionID)
+	}
+	order[m.focusCol], order[j] = order[j], order[m.focusCol]
+	// Hidden columns keep their place in the saved order.
+	for _, id := range m.colPrefs.Order {
+		if !slices.Contains(order, id) {
+			order = append(order, id)
+		}
+	}
+	m.colPrefs.Order = order
+	return m, m.applyColumnPrefs()
+}
+
+// applyColumnPrefs rebuilds the columns under the changed layout and saves
+// it. The write is inline, like the read: it is tiny, and writes from
+// background commands could land out of order. When the layout can't be
+// saved the status line says so, and the returned command clears it.
+func (m *Model) applyColumnPrefs() tea.Cmd {
+	m.replaceProject(m.project)
+	if m.project == nil {
+		return nil
+	}
+	var why string
+	if m.opts.Layouts == nil {
+		why = "no cache directory"
+	} else if err := m.opts.Layouts.SaveColumns(m.columnPrefsKey(m.project), m.colPrefs); err != nil {
+		why = err.Error()
+	}
+	if why == "" {
+		return nil
+	}
+	note := fmt.Sprintf("Column layout not saved (%s).", why)
+	if m.status != "" {
+		note = m.status + " " + note
+	}
+	m.status = note
+	return clearStatusAfter(statusLifetime)
+}
+
+// columnWindow picks the columns that fit the terminal, keeping the focused
+// one near the middle, and the width of each. Collapsed columns take
+// collapsedColW and the others share the rest.
+func (m Model) columnWindow() (first, last int, widths []int) {
+	if len(m.columns) == 0 {
+		return 0, 0, nil
+	}
+	minW := func(i int) int {
+		if m.strip(i) {
+			return collapsedColW
+		}
+		return minColW
+	}
+	first = max(m.focusCol, 0)
+	last = first + 1
+	used := minW(first)
+	// Grow left and right alternately, left first, while columns fit.
+	for left := true; ; left = !left {
+		switch {
+		case left && first > 0 && used+minW(first-1) <= m.width:
+			first--
+			used += minW(first)
+		case !left && last < len(m.columns) && used+minW(last) <= m.width:
+			used += minW(last)
+			last++
+		case (first == 0 || used+minW(first-1) > m.width) && (last == len(m.columns) || used+minW(last) > m.width):
+			return first, last, m.columnWidths(first, last)
+		}
+	}
+}
+
+func (m Model) columnWidths(first, last int) []int {
+	rest, open := m.width, 0
+	for i := first; i < last; i++ {
+		if m.strip(i) {
+			rest -= collapsedColW
+		} else {
+			open++
+		}
+	}
+	widths := make([]int, 0, last-first)
+	for i := first; i < last; i++ {
+		w := collapsedColW
+		if !m.strip(i) {
+			w = max(rest/open, minColW)
+		}
+		widths = append(widths, w)
+	}
+	return widths
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/shuntaka9576/kanban/internal/cache"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+func columnNames(m Model) []string {
+	names := make([]string, 0, len(m.columns))
+	for _, c := range m.columns {
+		name := c.name
+		if c.collapsed {
+			name += " (collapsed)"
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestE2E_ColumnLayoutPersistsPerProject(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(6))
+	// No board cache, as with --no-cache: the layout is kept anyway.
+	layouts := cache.New(t.TempDir())
+
+	h := newHarness(t, b, Options{Layouts: layouts})
+	h.waitLoaded(6)
+	h.key("x") // hide Todo; focus lands on Doing
+	h.key(">") // Doing after Done
+	if h.m.columns[h.m.focusCol].name != "Doing" {
+		t.Fatalf("focus is on %q, want it to follow Doing", h.m.columns[h.m.focusCol].name)
+	}
+	h.key("z") // collapse Doing
+	want := []string{"Done", "Doing (collapsed)"}
+	if got := columnNames(h.m); !slices.Equal(got, want) {
+		t.Fatalf("columns = %v, want %v", got, want)
+	}
+	if h.m.columns[h.m.focusCol].name != "Done" {
+		t.Fatalf("focus is on %q, want it to leave the strip for Done", h.m.columns[h.m.focusCol].name)
+	}
+
+	// A later launch starts from the saved layout.
+	h = newHarness(t, b, Options{Layouts: layouts})
+	h.waitLoaded(6)
+	if got := columnNames(h.m); !slices.Equal(got, want) {
+		t.Fatalf("columns after restart = %v, want %v", got, want)
+	}
+	mustContain(t, h.m.View(), "Done (2)")
+
+	h.key("X")
+	want = []string{"Done", "Doing (collapsed)", "Todo"}
+	if got := columnNames(h.m); !slices.Equal(got, want) {
+		t.Fatalf("columns after X = %v, want %v", got, want)
+	}
+}
+
+func TestE2E_ColumnLayoutWithoutStoreSaysSo(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t, ghfake.New(fakeProject(6)), Options{})
+	h.waitLoaded(6)
+	h.key("z")
+	mustContain(t, h.m.status, "Column layout not saved (no cache directory).")
+	h.key("x")
+	mustContain(t, h.m.status, "hidden", "Column layout not saved")
+}
+
+func TestColumnWindow_CollapsedColumnsMakeRoom(t *testing.T) {
+	t.Parallel()
+	m := Model{width: 60, focusCol: 0}
+	for _, name := range []string{"A", "B", "C", "D"} {
+		m.columns = append(m.columns, column{name: name})
+	}
+	if first, last, _ := m.columnWindow(); first != 0 || last != 2 {
+		t.Fatalf("window = [%d, %d), want two 22-wide columns in 60", first, last)
+	}
+
+	m.columns[1].collapsed = true
+	m.columns[2].collapsed = true
+	first, last, widths := m.columnWindow()
+	if first != 0 || last != 4 {
+		t.Fatalf("window = [%d, %d), want every column", first, last)
+	}
+	if want := []int{23, collapsedColW, collapsedColW, 23}; !slices.Equal(widths, want) {
+		t.Fatalf("widths = %v, want %v", widths, want)
+	}
+}
+
+func TestColumnWindow_FocusOpensCollapsedColumn(t *testing.T) {
+	t.Parallel()
+	m := Model{width: 60, focusCol: 1}
+	for _, name := range []string{"A", "B", "C"} {
+		m.columns = append(m.columns, column{name: name, collapsed: name != "A"})
+	}
+	_, _, widths := m.columnWindow()
+	if want := []int{26, 26, collapsedColW}; !slices.Equal(widths, want) {
+		t.Fatalf("widths = %v, want the focused strip drawn open: %v", widths, want)
+	}
+}
+
+func TestE2E_CollapsedColumnsArePerGroupByField(t *testing.T) {
+	t.Parallel()
+	p := fakeProject(6)
+	p.Items[0].StatusOptionID = ""
+	delete(p.Items[0].Fields, "Status")
+	p.Fields = append(p.Fields, gh.SingleSelectField{ID: "F_prio", Name: "Priority", Options: []gh.SingleSelectOption{{ID: "p0", Name: "P0"}}})
+	layouts := cache.New(t.TempDir())
+
+	h := newHarness(t, ghfake.New(p), Options{Layouts: layouts})
+	h.waitLoaded(6)
+	h.key("h") // wrap round to No Status
+	h.key("z")
+	if last := h.m.columns[len(h.m.columns)-1]; last.name != "No Status" || !last.collapsed {
+		t.Fatalf("last column = %q, collapsed %v; want No Status collapsed", last.name, last.collapsed)
+	}
+
+	// No Priority shares No Status's pseudo option ID, but not its layout.
+	h = newHarness(t, ghfake.New(p), Options{Layouts: layouts, Layout: BoardLayout{GroupBy: "Priority"}})
+	h.waitLoaded(6)
+	for _, c := range h.m.columns {
+		if c.collapsed {
+			t.Fatalf("grouped by Priority, %q is collapsed by Status's layout", c.name)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+var _ Backend = (*ghfake.Backend)(nil)
+
+// harness drives a Model or a Session the way the bubbletea runtime does:
+// every returned command runs in its own goroutine and its message is fed
+// back to Update. Timers (spinner, status expiry, retries) run for real, so
+// tests wait for a condition rather than for the program to go idle. board
+// picks the board on screen out of m.
+type harness[M tea.Model] struct {
+	t     *testing.T
+	m     M
+	board func(M) Model
+	msgs  chan tea.Msg
+	done  chan struct{}
+}
+
+func newHarness(t *testing.T, b Backend, opts Options) *harness[Model] {
+	t.Helper()
+	return drive(t, New(b, gh.ProjectSpec{Number: 7}, "#7", opts), func(m Model) Model { return m })
+}
+
+func drive[M tea.Model](t *testing.T, m M, board func(M) Model) *harness[M] {
+	t.Helper()
+	h := &harness[M]{
+		t:     t,
+		m:     m,
+		board: board,
+		msgs:  make(chan tea.Msg),
+		done:  make(chan struct{}),
+	}
+	t.Cleanup(func() { close(h.done) })
+	h.dispatch(tea.WindowSizeMsg{Width: 120, Height: 40})
+	h.run(h.m.Init())
+	return h
+}
+
+func (h *harness[M]) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	go func() {
+		select {
+		case h.msgs <- cmd():
+		case <-h.done:
+		}
+	}()
+}
+
+func (h *harness[M]) dispatch(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case nil, tea.QuitMsg:
+		return
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			h.run(cmd)
+		}
+		return
+	}
+	next, cmd := h.m.Update(msg)
+	h.m = next.(M)
+	h.run(cmd)
+}
+
+func (h *harness[M]) key(k string) {
+	h.dispatch(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+}
+
+func (h *harness[M]) waitFor(what string, cond func(M) bool) {
+	h.t.Helper()
+	deadline := time.After(3 * time.Second)
+	for !cond(h.m) {
+		select {
+		case msg := <-h.msgs:
+			h.dispatch(msg)
+		case <-deadline:
+			b := h.board(h.m)
+			h.t.Fatalf("timed out waiting for %s (status %q, err %v)", what, b.status, b.err)
+		}
+	}
+}
+
+// waitLoaded waits until n items are on the board and nothing is loading.
+// In a Session that is the board on screen.
+func (h *harness[M]) waitLoaded(n int) {
+	h.t.Helper()
+	h.waitFor(fmt.Sprintf("%d items loaded", n), func(s M) bool {
+		m := h.board(s)
+		return m.bootstrapped && !m.paginating && m.staged == nil && m.movingItem == "" && !m.syncing && m.loadedItems == n
+	})
+}
+
+// columnOf returns the name of the column holding itemID, or "".
+func columnOf(m Model, itemID string) string {
+	for _, c := range m.columns {
+		for _, it := range c.items {
+			if it.ID == itemID {
+				return c.name
+			}
+		}
+	}
+	return ""
+}
+
+// fakeProject builds project #7 with n items spread over Todo, Doing and
+// Done.
+func fakeProject(n int) *gh.Project {
+	status := gh.SingleSelectField{
+		ID: "F_status", Name: "Status",
+		Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}, {ID: "doing", Name: "Doing"}, {ID: "done", Name: "Done"}},
+	}
+	p := &gh.Project{ID: "P_7", Number: 7, Title: "Sprint", Status: status, Fields: []gh.SingleSelectField{status}}
+	for i := range n {
+		opt := status.Options[i%len(status.Options)]
+		p.Items = append(p.Items, gh.Item{
+			ID:             "I_" + itoa(i),
+			ContentType:    gh.ContentIssue,
+			Title:          "Task " + itoa(i),
+			Body:           "Body of task " + itoa(i),
+			Number:         i + 1,
+			Repository:     "acme/app",
+			URL:            "https://github.com/acme/app/issues/" + itoa(i+1),
+			StatusOptionID: opt.ID,
+			Fields:         map[string]string{"Status": opt.Name},
+		})
+	}
+	return p
+}
+
+func TestE2E_PagesInWholeBoard(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(250))
+	b.Latency = 5 * time.Millisecond
+	h := newHarness(t, b, Options{})
+
+	if got := h.m.View(); !strings.Contains(got, "Resolving") {
+		t.Fatalf("want the loading view before the first response:\n%s", got)
+	}
+	h.waitLoaded(250)
+
+	want := []string{"BootstrapBySpec", "FetchItemsPage", "FetchItemsPage"}
+	if got := b.Calls(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("calls = %v, want %v", got, want)
+	}
+	for _, c := range h.m.columns {
+		if want := 83 + boolInt(c.name == "Todo"); len(c.items) != want {
+			t.Errorf("column %s has %d items, want %d", c.name, len(c.items), want)
+		}
+	}
+}
+
+func TestE2E_LargeBoardLoadsByPartition(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(gh.MinPartitionedItems + 20))
+	h := newHarness(t, b, Options{})
+	h.waitLoaded(gh.MinPartitionedItems + 20)
+
+	for _, call := range b.Calls() {
+		if call == "FetchItemsPage" {
+			t.Fatalf("partitioned load fell back to sequential paging: %v", b.Calls())
+		}
+	}
+}
+
+func TestE2E_FailedPartitionIsRetriedAlone(t *testing.T) {
+	t.Parallel()
+	n := gh.MinPartitionedItems + 20
+	b := ghfake.New(fakeProject(n))
+	b.FailNext("FetchPartitions") // one partition's first page
+	h := newHarness(t, b, Options{})
+	h.waitFor("the failure on the status line", func(m Model) bool { return strings.Contains(m.status, "partition failed") })
+	h.waitLoaded(n)
+
+	for _, call := range b.Calls() {
+		if call == "FetchItemsPage" {
+			t.Fatalf("a failed partition fell back to sequential paging: %v", b.Calls())
+		}
+	}
+	if h.m.status != "" {
+		t.Fatalf("status = %q after the retry completed the board", h.m.status)
+	}
+}
+
+func TestE2E_MoveItemReloadsBoard(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(6))
+	h := newHarness(t, b, Options{})
+	h.waitLoaded(6)
+
+	h.key("n") // I_0: Todo -> Doing
+	if h.m.movingItem != "I_0" {
+		t.Fatalf("movingItem = %q, want I_0", h.m.movingItem)
+	}
+	h.waitLoaded(6)
+
+	if got := columnOf(h.m, "I_0"); got != "Doing" {
+		t.Fatalf("I_0 is in %q, want Doing", got)
+	}
+	if got := b.Project().Items[0].StatusOptionID; got != "doing" {
+		t.Fatalf("server status = %q, want doing", got)
+	}
+}
+
+func TestE2E_MoveFailureKeepsBoard(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(6))
+	b.FailNext("UpdateItemStatus", errors.New("forbidden"))
+	h := newHarness(t, b, Options{})
+	h.waitLoaded(6)
+
+	h.key("n")
+	h.waitFor("move error", func(m Model) bool { return m.err != nil })
+
+	if h.m.movingItem != "" {
+		t.Fatalf("movingItem = %q after failure, want empty", h.m.movingItem)
+	}
+	if got := columnOf(h.m, "I_0"); got != "Todo" {
+		t.Fatalf("I_0 is in %q, want Todo", got)
+	}
+	mustContain(t, h.m.View(), "forbidden")
+}
+
+func TestE2E_FailedPageRetriesThenResumesOnR(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(250))
+	b.FailNext("FetchItemsPage", errors.New("bad gateway"), errors.New("bad gateway"))
+	h := newHarness(t, b, Options{})
+
+	h.waitFor("page failure", func(m Model) bool { return m.failedPage })
+	if h.m.loadedItems != 100 || h.m.nextCursor == "" {
+		t.Fatalf("loaded %d items, cursor %q; want the first page kept and the cursor", h.m.loadedItems, h.m.nextCursor)
+	}
+
+	// Deliver the automatic retry without waiting out its backoff.
+	h.dispatch(retryPageMsg{cursor: h.m.nextCursor})
+	h.waitFor("second failure", func(m Model) bool { return m.failedPage && !m.paginating })
+
+	h.key("r")
+	h.waitLoaded(250)
+	if h.m.failedPage || h.m.err != nil {
+		t.Fatalf("failedPage = %v, err = %v after resuming", h.m.failedPage, h.m.err)
+	}
+}
+
+func TestE2E_SyncPicksUpRemoteChanges(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(6))
+	h := newHarness(t, b, Options{})
+	h.waitLoaded(6)
+
+	if err := b.SetStatus("I_1", "done"); err != nil {
+		t.Fatal(err)
+	}
+	b.RemoveItem("I_2")
+	b.AddItem(gh.Item{ID: "I_new", Title: "Fresh", StatusOptionID: "todo", Fields: map[string]string{"Status": "Todo"}})
+
+	h.key("R")
+	if !h.m.syncing {
+		t.Fatal("R on a complete board should start a delta sync")
+	}
+	h.waitLoaded(6)
+
+	for id, want := range map[string]string{"I_1": "Done", "I_2": "", "I_new": "Todo"} {
+		if got := columnOf(h.m, id); got != want {
+			t.Errorf("%s is in %q, want %q", id, got, want)
+		}
+	}
+	if _, ok := h.m.changed["I_1"]; !ok {
+		t.Errorf("I_1 should be highlighted as changed: %v", h.m.changed)
+	}
+	mustContain(t, h.m.status, "2 changed, 1 removed")
+	if got := b.Calls(); got[len(got)-1] != "FetchItemIDs" {
+		t.Errorf("a removal should trigger the id sweep; calls = %v", got)
+	}
+}
+
+// Not parallel: swaps the package-level clipboard writer.
+func TestE2E_YankCopiesMarkdown(t *testing.T) {
+	var copied string
+	orig := writeClipboard
+	writeClipboard = func(s string) error { copied = s; return nil }
+	t.Cleanup(func() { writeClipboard = orig })
+
+	b := ghfake.New(fakeProject(3))
+	b.SetContext("I_0", &gh.ItemContext{
+		ContentType: gh.ContentIssue, RepoNameOwner: "acme/app", Number: 1,
+		Title: "Task 0", Body: "Steps to reproduce", State: "OPEN",
+		Comments: []gh.Comment{{Author: "bob", Body: "seen it too"}}, TotalComments: 1,
+	})
+	h := newHarness(t, b, Options{})
+	h.waitLoaded(3)
+
+	h.key("y")
+	h.waitFor("yank", func(m Model) bool { return m.yanking == "" && m.status != "Fetching item context..." })
+
+	mustContain(t, copied, "Task 0")
+	mustContain(t, copied, "seen it too")
+	mustContain(t, h.m.status, "Copied")
+}
+
+func TestE2E_BootstrapFailureThenRefresh(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(3))
+	b.FailNext("BootstrapBySpec", errors.New("connection reset"))
+	h := newHarness(t, b, Options{})
+
+	h.waitFor("bootstrap error", func(m Model) bool { return m.err != nil })
+	mustContain(t, h.m.View(), "connection reset")
+
+	h.key("R")
+	h.waitLoaded(3)
+	if h.m.err != nil {
+		t.Fatalf("err = %v after refresh", h.m.err)
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
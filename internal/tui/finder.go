@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// finder is the find overlay: a query typed or pasted by the user and the
+// loaded items matching it, best first.
+type finder struct {
+	query   string
+	results []gh.Item
+	cursor  int
+}
+
+func (m Model) openFinder() (tea.Model, tea.Cmd) {
+	if m.project == nil {
+		return m, nil
+	}
+	m.finder = &finder{results: m.project.Items}
+	return m, nil
+}
+
+// finderKey edits the query, moves through the results, or jumps to the
+// chosen card.
+func (m Model) finderKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := *m.finder
+	switch msg.String() {
+	case quitKey:
+		return m, tea.Quit
+	case "esc":
+		m.finder = nil
+		return m, nil
+	case "enter":
+		m.finder = nil
+		if f.cursor < len(f.results) {
+			return m.jumpTo(f.results[f.cursor])
+		}
+		m.status = fmt.Sprintf("No loaded card matches %q.", f.query)
+		return m, clearStatusAfter(statusLifetime)
+	case "up", "ctrl+p", "ctrl+k":
+		f.cursor = max(f.cursor-1, 0)
+	case "down", "ctrl+n", "tab":
+		f.cursor = min(f.cursor+1, max(len(f.results)-1, 0))
+	case "backspace":
+		r := []rune(f.query)
+		f.query = string(r[:max(len(r)-1, 0)])
+		m.search(&f)
+	case "ctrl+u":
+		f.query = ""
+		m.search(&f)
+	default:
+		if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+			return m, nil
+		}
+		// Pastes arrive as one message; a trailing newline from the
+		// clipboard is not part of the query.
+		if msg.Type == tea.KeySpace {
+			f.query += " "
+		} else {
+			f.query += strings.TrimRight(string(msg.Runes), "\r\n")
+		}
+		m.search(&f)
+	}
+	m.finder = &f
+	return m, nil
+}
+
+func (m Model) search(f *finder) {
+	f.results = board.SearchItems(m.project.Items, f.query)
+	f.cursor = 0
+}
+
+// jumpTo focuses the card's column and puts the column cursor on it.
+func (m Model) jumpTo(it gh.Item) (tea.Model, tea.Cmd) {
+	for i, col := range m.columns {
+		for j, c := range col.items {
+			if c.ID == it.ID {
+				m.focusCol = i
+				m.columns[i].cursor = j
+				return m, nil
+			}
+		}
+	}
+	m.status = fmt.Sprintf("%s is loaded but not on the board: its column is hidden or filtered out.", itemRef(it))
+	return m, clearStatusAfter(statusLifetime)
+}
+
+// itemRef names an item in messages: "#12" or its title for drafts.
+func itemRef(it gh.Item) string {
+	if it.Number > 0 {
+		return fmt.Sprintf("#%d", it.Number)
+	}
+	return fmt.Sprintf("%q", it.Title)
+}
+
+// renderFinder draws the find overlay centred in a width x height area.
+func (m Model) renderFinder(height int) string {
+	f := m.finder
+	w := min(max(m.width-8, 20), 100)
+	where := make(map[string]string, len(m.project.Items))
+	for _, col := range m.columns {
+		for _, it := range col.items {
+			where[it.ID] = col.name
+		}
+	}
+
+	lines := []string{
+		m.st.title.Render("Find: ") + f.query + "▏",
+		m.st.muted.Render(fmt.Sprintf("%d of %d cards · title, #number, repo, @assignee or a URL", len(f.results), len(m.project.Items))),
+		"",
+	}
+	rows := max(height-len(lines)-4, 1)
+	start := 0
+	if f.cursor >= rows {
+		start = f.cursor - rows + 1
+	}
+	for i := start; i < len(f.results) && i < start+rows; i++ {
+		it := f.results[i]
+		col := where[it.ID]
+		if col == "" {
+			col = "not on the board"
+		}
+		label := strings.TrimSpace(fmt.Sprintf("%s %s", numberLabel(it), it.Title))
+		meta := " · " + col
+		if it.Repository != "" {
+			meta = " · " + it.Repository + meta
+		}
+		line := truncate(label, w-2-min(lipgloss.Width(meta), w/2)) + m.st.muted.Render(truncate(meta, w/2))
+		if i == f.cursor {
+			line = m.st.selectedCard.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	box := m.st.body.Width(w).Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Top, box)
+}
+
+func numberLabel(it gh.Item) string {
+	if it.Number > 0 {
+		return fmt.Sprintf("#%d", it.Number)
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+func TestE2E_FinderJumpsToCard(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t, ghfake.New(fakeProject(9)), Options{})
+	h.waitLoaded(9)
+
+	h.dispatch(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if h.m.finder == nil {
+		t.Fatal("ctrl+p should open the finder")
+	}
+	h.key("task")
+	h.dispatch(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	h.key("7")
+	mustContain(t, h.m.View(), "Find: task 7", "#8 Task 7")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.m.finder != nil {
+		t.Fatal("enter should close the finder")
+	}
+	if it := h.m.currentItem(); h.m.columns[h.m.focusCol].name != "Doing" || it == nil || it.ID != "I_7" {
+		t.Fatalf("focus on %s, item %v; want Doing's I_7", h.m.columns[h.m.focusCol].name, it)
+	}
+
+	// A pasted URL, trailing newline and all.
+	h.key("/")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("https://github.com/acme/app/issues/3\n"), Paste: true})
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	if it := h.m.currentItem(); it == nil || it.ID != "I_2" {
+		t.Fatalf("URL jumped to %v, want I_2", it)
+	}
+
+	h.key("/")
+	h.key("nothing like this")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	mustContain(t, h.m.View(), `No loaded card matches "nothing like this".`)
+	if it := h.m.currentItem(); it == nil || it.ID != "I_2" {
+		t.Fatalf("a failed search moved the cursor to %v", it)
+	}
+}
+
+func TestE2E_FinderReportsFilteredCards(t *testing.T) {
+	t.Parallel()
+	filter, err := board.ParseFilter(`-"Task 1"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newHarness(t, ghfake.New(fakeProject(3)), Options{Layout: BoardLayout{Filter: filter}})
+	h.waitLoaded(3)
+
+	h.key("/")
+	h.key("#2")
+	mustContain(t, h.m.View(), "not on the board")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	mustContain(t, h.m.View(), "#2 is loaded but not on the board")
+}
@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Action is a command the board reacts to. Keys reach actions through a
+// Keymap, so every binding can be changed from the config file.
+type Action string
+
+const (
+	ActionColumnLeft    Action = "column-left"
+	ActionColumnRight   Action = "column-right"
+	ActionCursorDown    Action = "cursor-down"
+	ActionCursorUp      Action = "cursor-up"
+	ActionMoveNext      Action = "move-next"
+	ActionMovePrev      Action = "move-prev"
+	ActionOpen          Action = "open"
+	ActionOpenProject   Action = "open-project"
+	ActionYank          Action = "yank"
+	ActionYankExtended  Action = "yank-extended"
+	ActionSync          Action = "sync"
+	ActionResume        Action = "resume"
+	ActionLive          Action = "live"
+	ActionCollapse      Action = "collapse-column"
+	ActionHideColumn    Action = "hide-column"
+	ActionShowColumns   Action = "show-columns"
+	ActionShiftLeft     Action = "shift-column-left"
+	ActionShiftRight    Action = "shift-column-right"
+	ActionFind          Action = "find"
+	ActionSwitchProject Action = "switch-project"
+	ActionNextProject   Action = "next-project"
+	ActionPrevProject   Action = "prev-project"
+	ActionHelp          Action = "help"
+	ActionQuit          Action = "quit"
+)
+
+// quitKey always quits and cannot be rebound.
+const quitKey = "ctrl+c"
+
+type binding struct {
+	action Action
+	keys   []string
+	help   string
+}
+
+// defaultBindings is the stock keymap, in the order the help overlay lists
+// it.
+var defaultBindings = []binding{
+	{ActionColumnLeft, []string{"h", "left"}, "focus the column to the left"},
+	{ActionColumnRight, []string{"l", "right"}, "focus the column to the right"},
+	{ActionCursorDown, []string{"j", "down"}, "select the next card"},
+	{ActionCursorUp, []string{"k", "up"}, "select the previous card"},
+	{ActionMoveNext, []string{"n"}, "move the card to the next column"},
+	{ActionMovePrev, []string{"b"}, "move the card to the previous column"},
+	{ActionOpen, []string{"o"}, "open the card in the browser"},
+	{ActionOpenProject, []string{"O"}, "open the project in the browser"},
+	{ActionYank, []string{"y"}, "copy the card as Markdown"},
+	{ActionYankExtended, []string{"Y"}, "copy with timeline, reviews and diff"},
+	{ActionSync, []string{"R"}, "sync changes from GitHub"},
+	{ActionResume, []string{"r"}, "resume loading after a failed page"},
+	{ActionLive, []string{"w"}, "toggle live mode"},
+	{ActionCollapse, []string{"z"}, "collapse or expand the column"},
+	{ActionHideColumn, []string{"x"}, "hide the column"},
+	{ActionShowColumns, []string{"X"}, "show hidden columns"},
+	{ActionShiftLeft, []string{"<"}, "move the column left"},
+	{ActionShiftRight, []string{">"}, "move the column right"},
+	{ActionFind, []string{"ctrl+p", "/"}, "find a card by title, number, repo, assignee or URL"},
+	{ActionSwitchProject, []string{"P"}, "switch to another project"},
+	{ActionNextProject, []string{"]", "tab"}, "show the next open project"},
+	{ActionPrevProject, []string{"[", "shift+tab"}, "show the previous open project"},
+	{ActionHelp, []string{"?"}, "show or hide this help"},
+	{ActionQuit, []string{"q"}, "quit"},
+}
+
+// footerHelp lists what the footer advertises; paired actions share one
+// entry ("h/l col"). The rest is in the ? overlay.
+var footerHelp = []struct {
+	actions []Action
+	label   string
+}{
+	{[]Action{ActionColumnLeft, ActionColumnRight}, "col"},
+	{[]Action{ActionCursorDown, ActionCursorUp}, "cursor"},
+	{[]Action{ActionMoveNext, ActionMovePrev}, "move"},
+	{[]Action{ActionOpen}, "open"},
+	{[]Action{ActionOpenProject}, "project"},
+	{[]Action{ActionYank, ActionYankExtended}, "yank-md"},
+	{[]Action{ActionSync}, "sync"},
+	{[]Action{ActionLive}, "live"},
+	{[]Action{ActionHelp}, "help"},
+	{[]Action{ActionQuit}, "quit"},
+}
+
+// Keymap binds keys (as bubbletea spells them: "j", "ctrl+n", "left") to
+// actions. The zero value is the default keymap.
+type Keymap struct {
+	bindings []binding
+	byKey    map[string]Action
+}
+
+func DefaultKeymap() Keymap {
+	km, _ := NewKeymap(nil)
+	return km
+}
+
+// NewKeymap applies overrides (action name to its new keys) to the default
+// keymap. An override replaces all of the action's keys; an empty list
+// unbinds it. Unknown actions and keys bound to two actions are errors.
+func NewKeymap(overrides map[string][]string) (Keymap, error) {
+	bindings := make([]binding, len(defaultBindings))
+	copy(bindings, defaultBindings)
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		i := slices.IndexFunc(bindings, func(b binding) bool { return string(b.action) == name })
+		if i < 0 {
+			return Keymap{}, fmt.Errorf("unknown key action %q; valid actions: %s", name, actionNames())
+		}
+		keys := make([]string, 0, len(overrides[name]))
+		for _, k := range overrides[name] {
+			if k = strings.TrimSpace(k); k == "" {
+				return Keymap{}, fmt.Errorf("key for %q is empty", name)
+			}
+			keys = append(keys, k)
+		}
+		bindings[i].keys = keys
+	}
+
+	km := Keymap{bindings: bindings, byKey: make(map[string]Action)}
+	for _, b := range bindings {
+		for _, k := range b.keys {
+			if k == quitKey {
+				return Keymap{}, fmt.Errorf("%s always quits and cannot be bound to %s", quitKey, b.action)
+			}
+			if other, dup := km.byKey[k]; dup && other != b.action {
+				return Keymap{}, fmt.Errorf("key %q is bound to both %s and %s", k, other, b.action)
+			}
+			km.byKey[k] = b.action
+		}
+	}
+	return km, nil
+}
+
+func actionNames() string {
+	names := make([]string, 0, len(defaultBindings))
+	for _, b := range defaultBindings {
+		names = append(names, string(b.action))
+	}
+	return strings.Join(names, ", ")
+}
+
+func (k Keymap) resolved() Keymap {
+	if k.byKey == nil {
+		return DefaultKeymap()
+	}
+	return k
+}
+
+// action returns the action bound to key.
+func (k Keymap) action(key string) (Action, bool) {
+	if key == quitKey {
+		return ActionQuit, true
+	}
+	a, ok := k.resolved().byKey[key]
+	return a, ok
+}
+
+// keys returns the keys bound to a, primary first.
+func (k Keymap) keys(a Action) []string {
+	for _, b := range k.resolved().bindings {
+		if b.action == a {
+			return b.keys
+		}
+	}
+	return nil
+}
+
+// primary is the key shown for a in hints, "" when unbound.
+func (k Keymap) primary(a Action) string {
+	if keys := k.keys(a); len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// footer is the one-line help, e.g. "h/l col  j/k cursor  …  q quit".
+// Entries whose actions are all unbound are left out.
+func (k Keymap) footer() string {
+	return strings.Join(k.footerParts(), "  ")
+}
+
+// footerFit is the footer in at most width cells. Entries are dropped from
+// the right, sparing the last two (help and quit) while any other is left,
+// and "" when even those don't fit.
+func (k Keymap) footerFit(width int) string {
+	parts := k.footerParts()
+	for len(parts) > 0 {
+		if help := strings.Join(parts, "  "); lipgloss.Width(help) <= width {
+			return help
+		}
+		drop := len(parts) - 3
+		if drop < 0 {
+			return ""
+		}
+		parts = slices.Delete(parts, drop, drop+1)
+	}
+	return ""
+}
+
+func (k Keymap) footerParts() []string {
+	var parts []string
+	for _, f := range footerHelp {
+		var keys []string
+		for _, a := range f.actions {
+			if p := k.primary(a); p != "" {
+				keys = append(keys, p)
+			}
+		}
+		if len(keys) > 0 {
+			parts = append(parts, strings.Join(keys, "/")+" "+f.label)
+		}
+	}
+	return parts
+}
+
+// helpRows lists every binding as (keys, description) for the ? overlay.
+func (k Keymap) helpRows() [][2]string {
+	bindings := k.resolved().bindings
+	rows := make([][2]string, 0, len(bindings))
+	for _, b := range bindings {
+		all := b.keys
+		if b.action == ActionQuit {
+			all = append(slices.Clip(all), quitKey)
+		}
+		keys := "(unbound)"
+		if len(all) > 0 {
+			keys = strings.Join(all, ", ")
+		}
+		rows = append(rows, [2]string{keys, b.help})
+	}
+	return rows
+}
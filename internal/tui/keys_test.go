@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func TestNewKeymap(t *testing.T) {
+	t.Parallel()
+	km, err := NewKeymap(map[string][]string{
+		"yank":         {"c"},
+		"move-next":    {"L"},
+		"column-right": {"n", "right"},
+		"live":         {},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]Action{
+		"c":      ActionYank,
+		"L":      ActionMoveNext,
+		"n":      ActionColumnRight,
+		"right":  ActionColumnRight,
+		"j":      ActionCursorDown,
+		"ctrl+c": ActionQuit,
+	}
+	for key, want := range cases {
+		if got, ok := km.action(key); !ok || got != want {
+			t.Errorf("action(%q) = %q, %v; want %q", key, got, ok, want)
+		}
+	}
+	for _, freed := range []string{"y", "l", "w"} {
+		if a, ok := km.action(freed); ok {
+			t.Errorf("%q should be unbound, got %s", freed, a)
+		}
+	}
+
+	if got, want := km.footer(), "h/n col  j/k cursor  L/b move  o open  O project  c/Y yank-md  R sync  ? help  q quit"; got != want {
+		t.Errorf("footer = %q\nwant     %q", got, want)
+	}
+}
+
+func TestKeymap_FooterFitSparesHelpAndQuit(t *testing.T) {
+	t.Parallel()
+	km := DefaultKeymap()
+	full := km.footer()
+	if got := km.footerFit(len(full)); got != full {
+		t.Errorf("footerFit(full width) = %q, want %q", got, full)
+	}
+	if got, want := km.footerFit(40), "h/l col  j/k cursor  ? help  q quit"; got != want {
+		t.Errorf("footerFit(40) = %q, want %q", got, want)
+	}
+	if got := km.footerFit(10); got != "" {
+		t.Errorf("footerFit(10) = %q, want no help at all", got)
+	}
+}
+
+func TestNewKeymap_Errors(t *testing.T) {
+	t.Parallel()
+	cases := map[string]map[string][]string{
+		"unknown action":        {"yonk": {"c"}},
+		"empty key":             {"yank": {" "}},
+		"conflict with default": {"yank": {"j"}},
+		"conflict in overrides": {"yank": {"c"}, "open": {"c"}},
+		"ctrl+c is reserved":    {"yank": {"ctrl+c"}},
+	}
+	for name, overrides := range cases {
+		if _, err := NewKeymap(overrides); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+	_, err := NewKeymap(map[string][]string{"yank": {"j"}})
+	if err == nil || !strings.Contains(err.Error(), `"j" is bound to both`) {
+		t.Errorf("conflict error = %v, want it to name the key", err)
+	}
+}
+
+func TestView_HelpOverlayFollowsKeymap(t *testing.T) {
+	km, err := NewKeymap(map[string][]string{"yank": {"c"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(nil, gh.ProjectSpec{Title: "Sample Project"}, `"Sample Project"`, Options{Keymap: km})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = out.(Model)
+	mustContain(t, m.View(), "c/Y yank-md", "? help")
+
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("?")})
+	m = out.(Model)
+	view := m.View()
+	mustContain(t, view, "Keys", "copy the card as Markdown", "q, ctrl+c", "press any key to close")
+	if strings.Count(view, "\n") != strings.Count(newSizedModel(t, 120, 40).View(), "\n") {
+		t.Error("the overlay should keep the screen height")
+	}
+
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if m = out.(Model); m.showHelp {
+		t.Fatal("any key should close the overlay")
+	}
+}
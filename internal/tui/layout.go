@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// BoardLayout decides how loaded items become columns. The zero value is
+// one column per Status option, in GitHub's order, with every item shown.
+type BoardLayout struct {
+	// GroupBy names the SingleSelect field forming the columns; moving a
+	// card sets this field. "" means Status.
+	GroupBy string
+	Filter  board.Filter
+	Sort    board.Sort
+	// Hidden lists column names (case-insensitive) left off the board.
+	Hidden []string
+	// Limits sets WIP limits by column name (case-insensitive). Columns
+	// not listed take a limit from their option description, e.g. "(3)".
+	Limits map[string]int
+	// ConfirmLimits asks before a move takes a column over its limit.
+	ConfirmLimits bool
+}
+
+func (l BoardLayout) isDefault() bool {
+	return l.GroupBy == "" && l.Filter.Empty() && l.Sort.Empty() && len(l.Hidden) == 0
+}
+
+// UsesFields reports whether the layout reads field values beyond Status,
+// so the board queries must fetch them.
+func (l BoardLayout) UsesFields() bool {
+	return l.Filter.UsesFields() || l.Sort.UsesFields()
+}
+
+func (l BoardLayout) hidden(name string) bool {
+	for _, h := range l.Hidden {
+		if strings.EqualFold(h, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// layoutColumns builds the columns for p under the configured layout and
+// the local column layout.
+func (m *Model) layoutColumns(p *gh.Project) []column {
+	return m.arrangeColumns(m.groupColumns(p))
+}
+
+// groupColumns groups, filters, sorts and hides p's items as configured. A
+// grouping field the project lacks falls back to Status and reports why.
+func (m *Model) groupColumns(p *gh.Project) []column {
+	l := m.opts.Layout
+	if p == nil || l.isDefault() {
+		return buildColumns(p)
+	}
+	groups, err := board.Group(p, l.GroupBy)
+	if err != nil {
+		m.err = err
+		groups, _ = board.Group(p, "")
+	}
+	cols := make([]column, 0, len(groups))
+	for _, g := range groups {
+		if l.hidden(g.Name) {
+			continue
+		}
+		cols = append(cols, column{optionID: g.OptionID, name: g.Name, color: g.Color, desc: g.Description, total: len(g.Items), items: l.Sort.Apply(l.Filter.Apply(g.Items))})
+	}
+	return cols
+}
+
+// groupField is the field a move sets: the grouping field, or Status.
+func (m Model) groupField() gh.SingleSelectField {
+	if m.opts.Layout.GroupBy != "" {
+		if f, err := board.GroupField(m.project, m.opts.Layout.GroupBy); err == nil {
+			return f
+		}
+	}
+	return m.project.Status
+}
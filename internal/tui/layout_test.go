@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+func TestLayoutColumns(t *testing.T) {
+	t.Parallel()
+	p := fakeProject(9)
+	prio := gh.SingleSelectField{ID: "F_prio", Name: "Priority", Options: []gh.SingleSelectOption{{ID: "p0", Name: "P0"}, {ID: "p1", Name: "P1"}}}
+	p.Fields = append(p.Fields, prio)
+	for i := range p.Items {
+		if i%2 == 0 {
+			p.Items[i].Fields["Priority"] = "P0"
+		}
+	}
+	sortDesc, _ := board.ParseSort("-number")
+	filter, _ := board.ParseFilter(`-"Task 3"`)
+
+	cases := []struct {
+		name   string
+		layout BoardLayout
+		want   map[string][]string
+	}{
+		{"default", BoardLayout{}, map[string][]string{
+			"Todo": {"I_0", "I_3", "I_6"}, "Doing": {"I_1", "I_4", "I_7"}, "Done": {"I_2", "I_5", "I_8"},
+		}},
+		{"hide and sort", BoardLayout{Hidden: []string{"done"}, Sort: sortDesc}, map[string][]string{
+			"Todo": {"I_6", "I_3", "I_0"}, "Doing": {"I_7", "I_4", "I_1"},
+		}},
+		{"group by priority", BoardLayout{GroupBy: "Priority", Filter: filter}, map[string][]string{
+			"P0": {"I_0", "I_2", "I_4", "I_6", "I_8"}, "P1": nil, "No Priority": {"I_1", "I_5", "I_7"},
+		}},
+	}
+	for _, tc := range cases {
+		m := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{Layout: tc.layout})
+		got := map[string][]string{}
+		for _, c := range m.layoutColumns(p) {
+			got[c.name] = nil
+			for _, it := range c.items {
+				got[c.name] = append(got[c.name], it.ID)
+			}
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("%s (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestE2E_GroupedBoardMovesSetGroupField(t *testing.T) {
+	t.Parallel()
+	p := fakeProject(3)
+	prio := gh.SingleSelectField{ID: "F_prio", Name: "Priority", Options: []gh.SingleSelectOption{{ID: "p0", Name: "P0"}, {ID: "p1", Name: "P1"}}}
+	p.Fields = append(p.Fields, prio)
+	p.Items[0].Fields["Priority"] = "P0"
+	b := ghfake.New(p)
+	h := newHarness(t, b, Options{Layout: BoardLayout{GroupBy: "Priority"}, Keymap: mustKeymap(t, map[string][]string{"move-next": {"L"}})})
+	h.waitLoaded(3)
+
+	h.key("n") // freed by the override
+	if h.m.movingItem != "" {
+		t.Fatal("n should no longer move cards")
+	}
+	h.key("L") // I_0: P0 -> P1
+	h.waitLoaded(3)
+
+	if got := columnOf(h.m, "I_0"); got != "P1" {
+		t.Fatalf("I_0 is in %q, want P1", got)
+	}
+	if it := b.Project().Items[0]; it.Fields["Priority"] != "P1" || it.StatusOptionID != "todo" {
+		t.Fatalf("server item = %+v, want Priority P1 and Status untouched", it)
+	}
+}
+
+func mustKeymap(t *testing.T, overrides map[string][]string) Keymap {
+	t.Helper()
+	km, err := NewKeymap(overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return km
+}
@@ -1,6 +1,11 @@
 package tui
 
 import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/shuntaka9576/kanban/internal/cache"
 	"github.com/shuntaka9576/kanban/internal/gh"
 )
 
@@ -9,12 +14,57 @@ const noStatusOptionID = ""
 type column struct {
 	optionID string
 	name     string
-	items    []gh.Item
-	cursor   int
+	color    string // GitHub option colour, tints the header and border
+	desc     string // option description, shown while the column is focused
+	total    int    // cards in the group before filtering, for WIP limits
+	// collapsed columns are drawn as a narrow strip with their count.
+	collapsed bool
+	items     []gh.Item
+	cursor    int
+}
+
+// Options carries the user-tunable settings passed in from the CLI.
+type Options struct {
+	// YankMaxComments caps how many comments (and, separately, review
+	// comments) a yank pulls in. 0 means fetch everything.
+	YankMaxComments int
+	// YankMaxDiffBytes caps the PR diff included by the extended yank (Y).
+	// 0 means gh.DefaultMaxDiffBytes.
+	YankMaxDiffBytes int
+	// YankBudget makes yank shrink its output to fit, dropping older and
+	// less-reacted comments first. The zero value means unlimited.
+	YankBudget YankBudget
+	// Cache, when set, paints the last snapshot on startup and stores each
+	// completed load. nil disables the on-disk cache.
+	Cache *cache.Handle
+	// Layouts keeps each project's column order, hidden and collapsed
+	// columns. It is separate from Cache so --no-cache and --replay still
+	// remember the layout; nil keeps it until quit.
+	Layouts *cache.Store
+	// Offline browses the cached snapshot only; the client may be nil and
+	// every network action is disabled.
+	Offline bool
+	// WatchInterval turns on live mode at startup, re-syncing the board in
+	// the background this often. 0 starts with live mode off (w toggles it).
+	WatchInterval time.Duration
+	// LazyBodies says the client's item selection leaves bodies out; the
+	// selected card's body is then fetched on demand for the detail pane.
+	LazyBodies bool
+	// Layout groups, filters, sorts and hides columns.
+	Layout BoardLayout
+	// Keymap binds keys to actions. The zero value is DefaultKeymap().
+	Keymap Keymap
+	// Theme colours the board. The zero value is the dark theme.
+	Theme Theme
+	// Owner is the login of the project's owner. A Session uses it to tell
+	// a board that hasn't loaded yet from another owner's project with the
+	// same number; "" matches any owner.
+	Owner string
 }
 
 type Model struct {
-	client       *gh.Client
+	client       Backend
+	opts         Options
 	spec         gh.ProjectSpec
 	specLabel    string // human-friendly project label for the loading view (e.g. "Sprint Backlog" or "#2")
 	project      *gh.Project
@@ -32,19 +82,58 @@ type Model struct {
 	yanking      string
 	status       string
 	err          error
+	ascii        bool        // draw borders with plain ASCII (print snapshots)
+	cachedAt     time.Time   // non-zero while the board shows a cached snapshot
+	staged       *gh.Project // fresh load being paged in behind a cached board
+	lastSync     time.Time   // when the board was last known complete; zero forces a full reload
+	syncing      bool        // a delta sync is in flight
+	watching     bool        // live mode: background syncs on a timer
+	watchGen     int         // bumped on every (re)schedule; stale ticks are dropped
+	watchEvery   time.Duration
+	paused       bool                // in a Session's background: live mode's syncs wait
+	rateLimit    gh.RateLimit        // as reported by the last sync
+	changed      map[string]struct{} // items changed by the last sync, highlighted on the board
+	seen         map[string]struct{} // ids on the project being loaded, to dedupe overlapping pages
+	failedPage   bool                // an items page failed; nextCursor still points at it
+	pageRetries  int                 // automatic retries used for the failed page
+	bodies       map[string]string   // lazily loaded bodies by item id
+	bodyLoading  string
+	bodyFailed   string // the card whose body fetch failed while selected
+	partitions   <-chan gh.PartitionPage
+	cancelLoad   context.CancelFunc
+	keys         Keymap
+	showHelp     bool          // the ? overlay is open
+	confirmMove  bool          // a move over a WIP limit awaits y/n
+	confirmOpt   string        // option ID of the column that move targets
+	colPrefs     cache.Columns // local column order, hidden and collapsed
+	colPrefsID   string        // layout key colPrefs was loaded for
+	dragging     string        // item being dragged with the mouse
+	dragOver     int           // column under the pointer during a drag
+	finder       *finder       // the find overlay, nil when closed
+	st           styles
+
+	// failedPartitions are the filters of the running partitioned load that
+	// failed; partitionRetry is set once they have been fetched again.
+	failedPartitions []string
+	partitionRetry   bool
 }
 
-func New(client *gh.Client, spec gh.ProjectSpec, specLabel string) Model {
+func New(client Backend, spec gh.ProjectSpec, specLabel string, opts Options) Model {
 	return Model{
 		client:    client,
+		opts:      opts,
 		spec:      spec,
 		specLabel: specLabel,
+		watching:  opts.WatchInterval > 0 && !opts.Offline,
+		keys:      opts.Keymap.resolved(),
+		st:        opts.Theme.resolved().styles(),
 	}
 }
 
 func (m *Model) setProject(p *gh.Project) {
 	m.project = p
-	m.columns = buildColumns(p)
+	m.loadColumnPrefs(p)
+	m.columns = m.layoutColumns(p)
 	if m.focusCol >= len(m.columns) {
 		m.focusCol = 0
 	}
@@ -55,11 +144,117 @@ func (m *Model) setProject(p *gh.Project) {
 	m.loadedItems = len(p.Items)
 }
 
+// replaceProject swaps in a freshly loaded project without losing the user's
+// place: focus stays on the same column and each column's cursor on the same
+// item, as long as they still exist.
+func (m *Model) replaceProject(p *gh.Project) {
+	focusID, hadFocus := "", len(m.columns) > 0
+	if hadFocus {
+		focusID = m.columns[m.focusCol].optionID
+	}
+	selected := make(map[string]string, len(m.columns))
+	for _, col := range m.columns {
+		if len(col.items) > 0 {
+			selected[col.optionID] = col.items[col.cursor].ID
+		}
+	}
+
+	m.setProject(p)
+
+	for i := range m.columns {
+		col := &m.columns[i]
+		if hadFocus && col.optionID == focusID {
+			m.focusCol = i
+		}
+		id, ok := selected[col.optionID]
+		if !ok {
+			continue
+		}
+		for j, it := range col.items {
+			if it.ID == id {
+				col.cursor = j
+				break
+			}
+		}
+	}
+}
+
+// mergeResult is the outcome of applying a delta sync to the loaded items.
+type mergeResult struct {
+	items   []gh.Item
+	changed map[string]struct{} // ids of new or modified items
+	removed int
+}
+
+// mergeItems applies a delta: changed items replace their old copy in place,
+// unknown ones are appended, and when alive is non-nil every item missing
+// from it is dropped.
+func mergeItems(items, delta []gh.Item, alive map[string]struct{}) mergeResult {
+	res := mergeResult{changed: make(map[string]struct{})}
+	byID := make(map[string]gh.Item, len(delta))
+	for _, it := range delta {
+		byID[it.ID] = it
+	}
+
+	res.items = make([]gh.Item, 0, len(items)+len(delta))
+	for _, it := range items {
+		if alive != nil {
+			if _, ok := alive[it.ID]; !ok {
+				res.removed++
+				continue
+			}
+		}
+		if fresh, ok := byID[it.ID]; ok {
+			if !reflect.DeepEqual(it, fresh) {
+				res.changed[it.ID] = struct{}{}
+			}
+			it = fresh
+			delete(byID, it.ID)
+		}
+		res.items = append(res.items, it)
+	}
+	// Keep the delta's order for newcomers.
+	for _, it := range delta {
+		if _, ok := byID[it.ID]; ok {
+			res.items = append(res.items, it)
+			res.changed[it.ID] = struct{}{}
+			delete(byID, it.ID)
+		}
+	}
+	return res
+}
+
+// addItems adds a page to the project being loaded (the staged one behind a
+// cached board, otherwise the displayed one), skipping items already seen.
+func (m *Model) addItems(items []gh.Item) {
+	fresh := make([]gh.Item, 0, len(items))
+	for _, it := range items {
+		if _, dup := m.seen[it.ID]; dup {
+			continue
+		}
+		if m.seen != nil {
+			m.seen[it.ID] = struct{}{}
+		}
+		fresh = append(fresh, it)
+	}
+	if m.staged != nil {
+		m.staged.Items = append(m.staged.Items, fresh...)
+		return
+	}
+	m.appendItems(fresh)
+}
+
 func (m *Model) appendItems(items []gh.Item) {
 	if len(items) == 0 || m.project == nil {
 		return
 	}
 	m.project.Items = append(m.project.Items, items...)
+	if m.customLayout() {
+		// Filtered, sorted, regrouped or rearranged columns are simply
+		// rebuilt.
+		m.replaceProject(m.project)
+		return
+	}
 
 	knownOption := make(map[string]int, len(m.columns))
 	noStatusIdx := -1
@@ -99,7 +294,7 @@ func buildColumns(p *gh.Project) []column {
 	}
 	cols := make([]column, 0, len(p.Status.Options)+1)
 	for _, opt := range p.Status.Options {
-		cols = append(cols, column{optionID: opt.ID, name: opt.Name})
+		cols = append(cols, column{optionID: opt.ID, name: opt.Name, color: opt.Color, desc: opt.Description})
 	}
 
 	hasNoStatus := false
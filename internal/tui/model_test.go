@@ -104,3 +104,37 @@ func TestBuildColumns_NoOrphans(t *testing.T) {
 		t.Fatalf("expected Todo column, got %q", got[0].name)
 	}
 }
+
+func TestMergeItems(t *testing.T) {
+	t.Parallel()
+	items := []gh.Item{
+		{ID: "i1", Title: "A", StatusOptionID: "todo"},
+		{ID: "i2", Title: "B", StatusOptionID: "todo"},
+		{ID: "i3", Title: "C", StatusOptionID: "done"},
+	}
+	delta := []gh.Item{
+		{ID: "i4", Title: "D", StatusOptionID: "todo"},
+		{ID: "i2", Title: "B", StatusOptionID: "done"},
+		{ID: "i1", Title: "A", StatusOptionID: "todo"}, // unchanged, re-sent by the day-wide filter
+	}
+
+	got := mergeItems(items, delta, nil)
+	want := []gh.Item{
+		{ID: "i1", Title: "A", StatusOptionID: "todo"},
+		{ID: "i2", Title: "B", StatusOptionID: "done"},
+		{ID: "i3", Title: "C", StatusOptionID: "done"},
+		{ID: "i4", Title: "D", StatusOptionID: "todo"},
+	}
+	if diff := cmp.Diff(want, got.items); diff != "" {
+		t.Fatalf("merged items mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]struct{}{"i2": {}, "i4": {}}, got.changed); diff != "" {
+		t.Fatalf("changed mismatch (-want +got):\n%s", diff)
+	}
+
+	alive := map[string]struct{}{"i1": {}, "i2": {}, "i4": {}}
+	got = mergeItems(items, delta, alive)
+	if got.removed != 1 || len(got.items) != 3 {
+		t.Fatalf("with sweep: removed=%d items=%d, want 1 and 3", got.removed, len(got.items))
+	}
+}
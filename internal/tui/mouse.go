@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hit is what a screen cell of the board holds.
+type hit struct {
+	col  int // index into m.columns
+	item int // index into the column's items, -1 for its header or border
+
+	// more is the "+ N more" line standing in for the column's last row.
+	more bool
+}
+
+// hitTest maps a screen position back to the column and card drawn there,
+// using the same window and geometry as renderBoard.
+func (m Model) hitTest(x, y int) (hit, bool) {
+	if !m.bootstrapped || m.project == nil || len(m.columns) == 0 {
+		return hit{}, false
+	}
+	boardLines := m.boardLines()
+	row := y - titleLines - 1 // below the title and the top border
+	if row < 0 || row >= boardLines-2 {
+		return hit{}, false
+	}
+
+	first, last, widths := m.columnWindow()
+	h := hit{col: -1, item: -1}
+	left := 0
+	for i := first; i < last; i++ {
+		if x >= left && x < left+widths[i-first] {
+			h.col = i
+			break
+		}
+		left += widths[i-first]
+	}
+	if h.col < 0 {
+		return hit{}, false
+	}
+
+	col := m.columns[h.col]
+	_, cardRows := boardRows(boardLines)
+	if m.strip(h.col) || row < 2 {
+		return h, true
+	}
+	start, end := windowItems(h.col == m.focusCol, col.cursor, len(col.items), cardRows)
+	switch idx := start + row - 2; {
+	case idx == end-1 && end < len(col.items):
+		h.more = true
+	case idx < end:
+		h.item = idx
+	}
+	return h, true
+}
+
+// handleMouse selects the clicked card, scrolls the column under the wheel
+// and moves a card dragged onto another column through the same path as
+// move-next/move-prev.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.modal() {
+		return m, nil
+	}
+	h, onBoard := m.hitTest(msg.X, msg.Y)
+
+	switch msg.Action {
+	case tea.MouseActionPress:
+		switch msg.Button {
+		case tea.MouseButtonWheelUp, tea.MouseButtonWheelDown:
+			if onBoard {
+				return m.scroll(h.col, msg.Button == tea.MouseButtonWheelDown)
+			}
+		case tea.MouseButtonLeft:
+			if !onBoard {
+				return m, nil
+			}
+			if h.more {
+				return m.scroll(h.col, true)
+			}
+			m.focusCol = h.col
+			col := &m.columns[h.col]
+			if h.item >= 0 {
+				col.cursor = h.item
+				m.dragging = col.items[h.item].ID
+				m.dragOver = h.col
+			}
+			return m, nil
+		}
+
+	case tea.MouseActionMotion:
+		if m.dragging != "" && onBoard {
+			m.dragOver = h.col
+		}
+
+	case tea.MouseActionRelease:
+		itemID := m.dragging
+		m.dragging = ""
+		if itemID == "" || !onBoard || h.col == m.focusCol {
+			return m, nil
+		}
+		if it := m.currentItem(); it == nil || it.ID != itemID {
+			return m, nil // the board changed under the drag
+		}
+		if m.opts.Offline {
+			m.status = offlineNotice
+			return m, clearStatusAfter(statusLifetime)
+		}
+		return m.guardedMove(h.col)
+	}
+	return m, nil
+}
+
+// scroll moves the cursor of column i one card down or up, focusing it.
+func (m Model) scroll(i int, down bool) (tea.Model, tea.Cmd) {
+	m.focusCol = i
+	col := &m.columns[i]
+	switch {
+	case down && col.cursor < len(col.items)-1:
+		col.cursor++
+	case !down && col.cursor > 0:
+		col.cursor--
+	}
+	return m, nil
+}
+
+// dragHint describes the drag in progress for the status line.
+func (m Model) dragHint() string {
+	it := m.currentItem()
+	if it == nil || it.ID != m.dragging || m.dragOver == m.focusCol || m.dragOver >= len(m.columns) {
+		return ""
+	}
+	return fmt.Sprintf("drop to move %q to %s", it.Title, m.columns[m.dragOver].name)
+}
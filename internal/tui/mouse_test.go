@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+func mouse(x, y int, action tea.MouseAction, button tea.MouseButton) tea.MouseMsg {
+	return tea.MouseMsg{X: x, Y: y, Action: action, Button: button}
+}
+
+func TestHitTest(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t, ghfake.New(fakeProject(6)), Options{})
+	h.waitLoaded(6)
+
+	// 120 columns wide: Todo, Doing and Done are 40 cells each. The title
+	// is row 0, the top border row 1, headers row 2, separators row 3.
+	cases := []struct {
+		x, y int
+		want hit
+		ok   bool
+	}{
+		{5, 4, hit{col: 0, item: 0}, true},
+		{45, 5, hit{col: 1, item: 1}, true},
+		{85, 2, hit{col: 2, item: -1}, true},
+		{85, 9, hit{col: 2, item: -1}, true}, // below the last card
+		{5, 0, hit{}, false},                 // title
+		{5, 40, hit{}, false},                // detail pane
+	}
+	for _, tc := range cases {
+		got, ok := h.m.hitTest(tc.x, tc.y)
+		if ok != tc.ok || got != tc.want {
+			t.Errorf("hitTest(%d, %d) = %+v, %v; want %+v, %v", tc.x, tc.y, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestE2E_MouseClickOnMoreScrolls(t *testing.T) {
+	t.Parallel()
+	h := newHarness(t, ghfake.New(fakeProject(150)), Options{})
+	h.waitLoaded(150)
+
+	_, cardRows := boardRows(h.m.boardLines())
+	y := titleLines + 2 + cardRows // Doing's last row, "+ N more"
+	mustContain(t, h.m.View(), "more")
+	if got, ok := h.m.hitTest(45, y); !ok || !got.more || got.item != -1 {
+		t.Fatalf("hitTest on + N more = %+v, %v; want the more line, no card", got, ok)
+	}
+	h.dispatch(mouse(45, y, tea.MouseActionPress, tea.MouseButtonLeft))
+	h.dispatch(mouse(45, y, tea.MouseActionRelease, tea.MouseButtonNone))
+	if h.m.focusCol != 1 || h.m.columns[1].cursor != 1 || h.m.dragging != "" {
+		t.Fatalf("focus %d, cursor %d, dragging %q; want Doing scrolled one card, no drag", h.m.focusCol, h.m.columns[1].cursor, h.m.dragging)
+	}
+}
+
+func TestE2E_MouseClickScrollAndDrag(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(6))
+	h := newHarness(t, b, Options{})
+	h.waitLoaded(6)
+
+	h.dispatch(mouse(45, 5, tea.MouseActionPress, tea.MouseButtonLeft))
+	h.dispatch(mouse(45, 5, tea.MouseActionRelease, tea.MouseButtonNone))
+	if it := h.m.currentItem(); h.m.focusCol != 1 || it == nil || it.ID != "I_4" {
+		t.Fatalf("click selected column %d, item %v; want Doing's I_4", h.m.focusCol, it)
+	}
+
+	h.dispatch(mouse(5, 4, tea.MouseActionPress, tea.MouseButtonWheelDown))
+	if it := h.m.currentItem(); h.m.focusCol != 0 || it == nil || it.ID != "I_3" {
+		t.Fatalf("wheel selected column %d, item %v; want Todo's I_3", h.m.focusCol, it)
+	}
+	h.dispatch(mouse(5, 4, tea.MouseActionPress, tea.MouseButtonWheelDown))
+	if it := h.m.currentItem(); it == nil || it.ID != "I_3" {
+		t.Fatalf("wheel past the last card selected %v, want it to stay on I_3", it)
+	}
+
+	// Drag I_0 from Todo onto Done.
+	h.dispatch(mouse(5, 4, tea.MouseActionPress, tea.MouseButtonLeft))
+	h.dispatch(mouse(60, 6, tea.MouseActionMotion, tea.MouseButtonLeft))
+	h.dispatch(mouse(85, 6, tea.MouseActionMotion, tea.MouseButtonLeft))
+	mustContain(t, h.m.View(), `drop to move "Task 0" to Done`)
+	h.dispatch(mouse(85, 6, tea.MouseActionRelease, tea.MouseButtonNone))
+	if h.m.movingItem != "I_0" {
+		t.Fatalf("movingItem = %q after the drop, want I_0", h.m.movingItem)
+	}
+	h.waitLoaded(6)
+	if got := columnOf(h.m, "I_0"); got != "Done" {
+		t.Fatalf("I_0 is in %q, want Done", got)
+	}
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// partitionPageMsg carries one page from a concurrent load. ch identifies the
+// load so pages from one abandoned by R are dropped.
+type partitionPageMsg struct {
+	page gh.PartitionPage
+	ch   <-chan gh.PartitionPage
+}
+
+type partitionsDoneMsg struct {
+	ch <-chan gh.PartitionPage
+}
+
+func waitPartitionCmd(ch <-chan gh.PartitionPage) tea.Cmd {
+	return func() tea.Msg {
+		page, ok := <-ch
+		if !ok {
+			return partitionsDoneMsg{ch: ch}
+		}
+		return partitionPageMsg{page: page, ch: ch}
+	}
+}
+
+// startPartitions loads the rest of a large board as parallel Status
+// partitions instead of following the cursor chain one page at a time.
+func (m Model) startPartitions(p *gh.Project, filters []string) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelLoad = cancel
+	m.partitions = m.client.FetchPartitions(ctx, p.ID, p.Status.ID, filters, gh.DefaultConcurrency)
+	return m, tea.Batch(waitPartitionCmd(m.partitions), tickCmd())
+}
+
+func (m *Model) stopPartitions() {
+	if m.cancelLoad != nil {
+		m.cancelLoad()
+	}
+	m.cancelLoad = nil
+	m.partitions = nil
+}
+
+func (m Model) handlePartitionPage(msg partitionPageMsg) (tea.Model, tea.Cmd) {
+	if msg.ch != m.partitions {
+		return m, nil
+	}
+	// A failed partition is not fatal: it is fetched again on its own once
+	// the others are done.
+	if err := msg.page.Err; err != nil {
+		m.failedPartitions = append(m.failedPartitions, msg.page.Filter)
+		m.status = fmt.Sprintf("partition failed: %v", err)
+		return m, waitPartitionCmd(msg.ch)
+	}
+	m.addItems(msg.page.Items)
+	return m, waitPartitionCmd(msg.ch)
+}
+
+func (m Model) handlePartitionsDone(msg partitionsDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.ch != m.partitions {
+		return m, nil
+	}
+	m.stopPartitions()
+	p := m.loadingProject()
+	if p == nil {
+		return m, nil
+	}
+	failed := m.failedPartitions
+	m.failedPartitions = nil
+	if len(p.Items) >= m.totalItems {
+		m.paginating = false
+		m.nextCursor = ""
+		m.status = ""
+		return m.finishLoad()
+	}
+	// Fetch just the failed partitions again, once; their pages that did
+	// arrive are deduped.
+	if len(failed) > 0 && !m.partitionRetry {
+		m.partitionRetry = true
+		m.status = fmt.Sprintf("retrying %d failed partition(s)…", len(failed))
+		return m.startPartitions(p, failed)
+	}
+	// Items on deleted Status options match no partition, and a partition
+	// may have failed twice; page through the board from the bootstrap
+	// cursor, deduping what we already have.
+	m.status = ""
+	if len(failed) > 0 {
+		m.status = "partition failed again; loading the rest page by page…"
+	}
+	return m, fetchItemsPageCmd(m.client, p.ID, p.Status.ID, m.nextCursor)
+}
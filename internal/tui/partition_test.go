@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func partitionedModel(t *testing.T, total int) (Model, chan gh.PartitionPage) {
+	t.Helper()
+	m := newSizedModel(t, 160, 40)
+	out, _ := m.Update(bootstrapMsg{project: &gh.Project{
+		ID: "P_1", Title: "Big",
+		Status: gh.SingleSelectField{ID: "F", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}, {ID: "done", Name: "Done"}}},
+		Items:  []gh.Item{{ID: "i1", Title: "first", StatusOptionID: "todo"}},
+	}, nextCursor: "CUR", totalItems: total})
+	m = out.(Model)
+	// Stand in for startPartitions, which needs a live client.
+	ch := make(chan gh.PartitionPage)
+	m.partitions = ch
+	return m, ch
+}
+
+func TestPartitions_DedupesAndFinishes(t *testing.T) {
+	m, ch := partitionedModel(t, 3)
+
+	out, cmd := m.Update(partitionPageMsg{ch: ch, page: gh.PartitionPage{Items: []gh.Item{
+		{ID: "i1", Title: "first", StatusOptionID: "todo"}, // also on the bootstrap page
+		{ID: "i2", Title: "second", StatusOptionID: "todo"},
+	}}})
+	m = out.(Model)
+	if cmd == nil {
+		t.Fatal("expected a command waiting for the next page")
+	}
+	out, _ = m.Update(partitionPageMsg{ch: ch, page: gh.PartitionPage{Items: []gh.Item{
+		{ID: "i3", Title: "third", StatusOptionID: "done"},
+	}}})
+	m = out.(Model)
+	mustContain(t, m.View(), "loaded 3 items")
+
+	// Pages from an abandoned load are ignored.
+	out, _ = m.Update(partitionPageMsg{ch: make(chan gh.PartitionPage), page: gh.PartitionPage{Items: []gh.Item{{ID: "x"}}}})
+	m = out.(Model)
+
+	out, _ = m.Update(partitionsDoneMsg{ch: ch})
+	m = out.(Model)
+	if m.paginating || m.loadedItems != 3 || m.lastSync.IsZero() {
+		t.Fatalf("paginating=%v loaded=%d synced=%v, want a complete board of 3", m.paginating, m.loadedItems, !m.lastSync.IsZero())
+	}
+	mustContain(t, m.View(), "Todo (2)", "Done (1)")
+}
+
+func TestPartitions_FallsBackWhenShort(t *testing.T) {
+	m, ch := partitionedModel(t, 5)
+	m.partitionRetry = true // the failed partition was already retried
+	out, _ := m.Update(partitionPageMsg{ch: ch, page: gh.PartitionPage{Err: errNoStatusField}})
+	out, cmd := out.(Model).Update(partitionsDoneMsg{ch: ch})
+	m = out.(Model)
+	if !m.paginating || cmd == nil || m.nextCursor != "CUR" {
+		t.Fatalf("expected sequential paging from the bootstrap cursor, paginating=%v cursor=%q", m.paginating, m.nextCursor)
+	}
+	if m.err != nil {
+		t.Fatalf("a failed partition should not surface as an error: %v", m.err)
+	}
+	mustContain(t, m.status, "partition failed again")
+
+	// The sequential pass re-sends i1; it must not be duplicated.
+	out, _ = m.Update(itemsPageMsg{items: []gh.Item{{ID: "i1", StatusOptionID: "todo"}, {ID: "i9", StatusOptionID: "gone"}}})
+	m = out.(Model)
+	if m.loadedItems != 2 {
+		t.Fatalf("loadedItems = %d, want 2", m.loadedItems)
+	}
+}
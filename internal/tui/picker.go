@@ -1,17 +1,22 @@
-package cli
+package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/shuntaka9576/kanban/internal/gh"
 )
 
+// pickerModel lists the owner's projects to choose from, both before the
+// first board (PickProject) and inside a Session to switch boards.
 type pickerModel struct {
 	projects []gh.ProjectSummary
+	note     string // shown under the header, e.g. why some projects are missing
 	cursor   int
 	selected *gh.ProjectSummary
+	done     bool // enter or cancel; the Session closes the picker
 }
 
 func (m pickerModel) Init() tea.Cmd { return nil }
@@ -23,6 +28,7 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	switch keyMsg.String() {
 	case "ctrl+c", "q", "esc":
+		m.done = true
 		return m, tea.Quit
 	case "j", "down":
 		if m.cursor < len(m.projects)-1 {
@@ -35,6 +41,7 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case "enter":
 		p := m.projects[m.cursor]
 		m.selected = &p
+		m.done = true
 		return m, tea.Quit
 	}
 	return m, nil
@@ -43,18 +50,31 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m pickerModel) View() string {
 	var b strings.Builder
 	b.WriteString("Select a project (j/k or ↑/↓, enter to confirm, q to cancel)\n\n")
+	if m.note != "" {
+		b.WriteString(m.note + "\n\n")
+	}
+	// Projects linked to the current repository can belong to other
+	// owners; then every row says whose it is.
+	showOwner := slices.ContainsFunc(m.projects, func(p gh.ProjectSummary) bool {
+		return p.OwnerLogin != m.projects[0].OwnerLogin
+	})
 	for i, p := range m.projects {
 		prefix := "  "
 		if i == m.cursor {
 			prefix = "▶ "
 		}
+		if showOwner {
+			prefix += p.OwnerLogin + " "
+		}
 		fmt.Fprintf(&b, "%s#%d  %s\n", prefix, p.Number, p.Title)
 	}
 	return b.String()
 }
 
-func pickProject(projects []gh.ProjectSummary) (*gh.ProjectSummary, error) {
-	final, err := tea.NewProgram(pickerModel{projects: projects}).Run()
+// PickProject asks for one of projects on the terminal, with note (if any)
+// under the header. It returns nil when the user cancels.
+func PickProject(projects []gh.ProjectSummary, note string) (*gh.ProjectSummary, error) {
+	final, err := tea.NewProgram(pickerModel{projects: projects, note: note}).Run()
 	if err != nil {
 		return nil, err
 	}
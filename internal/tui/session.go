@@ -0,0 +1,327 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// Session holds every board opened in one run. Boards stay in memory when
+// another one is shown, so switching back keeps the loaded items, focus and
+// cursors; only the shown board gets keys and the mouse, but all of them
+// keep receiving their own load, sync and timer messages.
+type Session struct {
+	switcher Switcher
+	boards   []Model
+	active   int
+	picker   *pickerModel // the project switcher, nil when closed
+	listing  bool         // a List call is in flight
+	width    int
+	height   int
+}
+
+// Switcher lets a Session open other projects. The zero value has no
+// switcher, as offline.
+type Switcher struct {
+	// List lists the projects to pick from, which may belong to several
+	// owners.
+	List func() ([]gh.ProjectSummary, error)
+	// Open returns the client and options for a picked project: a client
+	// of the project's owner, and e.g. that board's own cache.
+	Open func(gh.ProjectSummary) (Backend, Options, error)
+}
+
+// boardMsg routes a message produced by a board's command back to that
+// board.
+type boardMsg struct {
+	board int
+	msg   tea.Msg
+}
+
+type projectsMsg struct {
+	projects []gh.ProjectSummary
+	err      error
+}
+
+// NewSession starts a session showing first; sw opens the boards picked
+// later with the switcher.
+func NewSession(first Model, sw Switcher) Session {
+	return Session{switcher: sw, boards: []Model{first}}
+}
+
+// tag wraps cmd so its messages come back as boardMsg for board i. Batches
+// are tagged command by command; quitting is left alone.
+func tag(i int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.QuitMsg:
+			return msg
+		case tea.BatchMsg:
+			cmds := make(tea.BatchMsg, 0, len(msg))
+			for _, c := range msg {
+				cmds = append(cmds, tag(i, c))
+			}
+			return cmds
+		default:
+			return boardMsg{board: i, msg: msg}
+		}
+	}
+}
+
+func (s Session) Init() tea.Cmd {
+	return tag(0, s.boards[0].Init())
+}
+
+func (s Session) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case boardMsg:
+		if msg.board < len(s.boards) {
+			return s.updateBoard(msg.board, msg.msg)
+		}
+		return s, nil
+
+	case tea.WindowSizeMsg:
+		s.width, s.height = msg.Width, msg.Height
+		return s, s.resize()
+
+	case projectsMsg:
+		return s.openPicker(msg)
+
+	case tea.KeyMsg:
+		return s.handleKey(msg)
+
+	case tea.MouseMsg:
+		if s.picker != nil {
+			return s, nil
+		}
+		if s.tabLines() > 0 {
+			if msg.Y == 0 {
+				if i, ok := s.tabAt(msg.X); ok && msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+					return s.show(i)
+				}
+				return s, nil
+			}
+			msg.Y--
+		}
+		return s.updateBoard(s.active, msg)
+	}
+	return s.updateBoard(s.active, msg)
+}
+
+func (s Session) updateBoard(i int, msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := s.boards[i].Update(msg)
+	s.boards[i] = next.(Model)
+	return s, tag(i, cmd)
+}
+
+func (s Session) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == quitKey {
+		return s, tea.Quit
+	}
+	if s.picker != nil {
+		// The picker's own quit only closes it here.
+		next, _ := s.picker.Update(msg)
+		p := next.(pickerModel)
+		if !p.done {
+			s.picker = &p
+			return s, nil
+		}
+		s.picker = nil
+		if p.selected != nil {
+			return s.open(*p.selected)
+		}
+		return s, nil
+	}
+
+	b := s.boards[s.active]
+	if action, ok := b.keys.action(msg.String()); ok && !b.modal() {
+		switch action {
+		case ActionSwitchProject:
+			return s.listProjects()
+		case ActionNextProject:
+			return s.show((s.active + 1) % len(s.boards))
+		case ActionPrevProject:
+			return s.show((s.active + len(s.boards) - 1) % len(s.boards))
+		}
+	}
+	return s.updateBoard(s.active, msg)
+}
+
+// show brings board i to the front. Only the front board syncs in live mode;
+// the one sent to the back pauses and catches up when shown again.
+func (s Session) show(i int) (tea.Model, tea.Cmd) {
+	if i == s.active {
+		return s, nil
+	}
+	s.boards[s.active].pauseWatch()
+	s.active = i
+	next, cmd := s.boards[i].resumeWatch()
+	s.boards[i] = next
+	return s, tag(i, cmd)
+}
+
+// listProjects fetches the projects for the switcher.
+func (s Session) listProjects() (tea.Model, tea.Cmd) {
+	b := &s.boards[s.active]
+	if s.switcher.List == nil {
+		b.status = offlineNotice
+		return s, tag(s.active, clearStatusAfter(statusLifetime))
+	}
+	if s.listing {
+		return s, nil
+	}
+	s.listing = true
+	b.status = "Loading projects…"
+	list := s.switcher.List
+	return s, func() tea.Msg {
+		projects, err := list()
+		return projectsMsg{projects: projects, err: err}
+	}
+}
+
+func (s Session) openPicker(msg projectsMsg) (tea.Model, tea.Cmd) {
+	s.listing = false
+	b := &s.boards[s.active]
+	switch {
+	case msg.err != nil:
+		b.status = fmt.Sprintf("list projects: %v", msg.err)
+		return s, tag(s.active, clearStatusAfter(statusLifetime))
+	case len(msg.projects) == 0:
+		b.status = "No projects found."
+		return s, tag(s.active, clearStatusAfter(statusLifetime))
+	}
+	b.status = ""
+	p := pickerModel{projects: msg.projects}
+	// Start on the board being shown.
+	if i := slices.IndexFunc(msg.projects, func(ps gh.ProjectSummary) bool { return b.isProject(ps) }); i >= 0 {
+		p.cursor = i
+	}
+	s.picker = &p
+	return s, nil
+}
+
+// open shows the picked project, loading it unless a board for it is
+// already open.
+func (s Session) open(ps gh.ProjectSummary) (tea.Model, tea.Cmd) {
+	for i, b := range s.boards {
+		if b.isProject(ps) {
+			return s.show(i)
+		}
+	}
+	client, opts, err := s.switcher.Open(ps)
+	if err != nil {
+		s.boards[s.active].status = fmt.Sprintf("open %s #%d: %v", ps.OwnerLogin, ps.Number, err)
+		return s, tag(s.active, clearStatusAfter(statusLifetime))
+	}
+	spec := gh.ProjectSpec{Number: ps.Number}
+	b := New(client, spec, fmt.Sprintf("%q (#%d)", ps.Title, ps.Number), opts)
+	s.boards[s.active].pauseWatch()
+	s.boards = append(s.boards, b)
+	s.active = len(s.boards) - 1
+	// The tab line may have just appeared, so every board is resized.
+	return s, tea.Batch(s.resize(), tag(s.active, s.boards[s.active].Init()))
+}
+
+// resize passes the terminal size on to every board, less the tab line.
+func (s *Session) resize() tea.Cmd {
+	if s.width == 0 {
+		return nil
+	}
+	size := tea.WindowSizeMsg{Width: s.width, Height: s.height - s.tabLines()}
+	cmds := make([]tea.Cmd, 0, len(s.boards))
+	for i := range s.boards {
+		next, cmd := s.boards[i].Update(size)
+		s.boards[i] = next.(Model)
+		cmds = append(cmds, tag(i, cmd))
+	}
+	return tea.Batch(cmds...)
+}
+
+// tabLines is the height of the tab line: shown once a second board is
+// open.
+func (s Session) tabLines() int {
+	if len(s.boards) > 1 {
+		return 1
+	}
+	return 0
+}
+
+func (s Session) View() string {
+	if s.picker != nil {
+		return s.picker.View()
+	}
+	board := s.boards[s.active].View()
+	if s.tabLines() == 0 || board == "" {
+		return board
+	}
+	return s.renderTabs() + "\n" + board
+}
+
+// tabLabels are the tab captions, the active one in brackets.
+func (s Session) tabLabels() []string {
+	labels := make([]string, 0, len(s.boards))
+	for i, b := range s.boards {
+		label := b.specLabel
+		if b.project != nil {
+			label = b.project.Title
+		}
+		if i == s.active {
+			label = "[" + label + "]"
+		} else {
+			label = " " + label + " "
+		}
+		labels = append(labels, label)
+	}
+	return labels
+}
+
+func (s Session) renderTabs() string {
+	st := s.boards[s.active].st
+	parts := make([]string, 0, len(s.boards))
+	for i, label := range s.tabLabels() {
+		if i == s.active {
+			parts = append(parts, st.title.Render(label))
+		} else {
+			parts = append(parts, st.muted.Render(label))
+		}
+	}
+	return lipgloss.NewStyle().MaxWidth(s.width).Render(strings.Join(parts, " "))
+}
+
+// tabAt is the board whose tab is drawn at column x.
+func (s Session) tabAt(x int) (int, bool) {
+	left := 0
+	for i, label := range s.tabLabels() {
+		w := lipgloss.Width(label)
+		if x >= left && x < left+w {
+			return i, true
+		}
+		left += w + 1
+	}
+	return 0, false
+}
+
+// modal reports whether an overlay or prompt has the board's keys.
+func (m Model) modal() bool {
+	return m.showHelp || m.confirmMove || m.finder != nil
+}
+
+// isProject reports whether the board shows ps.
+func (m Model) isProject(ps gh.ProjectSummary) bool {
+	if m.project != nil {
+		return m.project.ID == ps.ID
+	}
+	if m.opts.Owner != "" && ps.OwnerLogin != "" && !strings.EqualFold(m.opts.Owner, ps.OwnerLogin) {
+		return false
+	}
+	return m.spec.Number == ps.Number
+}
@@ -0,0 +1,242 @@
+package tui
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+// twoProjects serves fakeProject as #7 "Sprint" and a second board as #8
+// "Roadmap", routing each call by project number or ID.
+type twoProjects struct {
+	*ghfake.Backend // #7, and anything keyed by item
+	roadmap         *ghfake.Backend
+}
+
+func newTwoProjects() *twoProjects {
+	p := fakeProject(2)
+	p.ID, p.Number, p.Title = "P_8", 8, "Roadmap"
+	return &twoProjects{Backend: ghfake.New(fakeProject(3)), roadmap: ghfake.New(p)}
+}
+
+func (b *twoProjects) pick(projectID string) *ghfake.Backend {
+	if projectID == "P_8" {
+		return b.roadmap
+	}
+	return b.Backend
+}
+
+func (b *twoProjects) BootstrapBySpec(spec gh.ProjectSpec) (*gh.BootstrapResult, error) {
+	if spec.Number == 8 {
+		return b.roadmap.BootstrapBySpec(spec)
+	}
+	return b.Backend.BootstrapBySpec(spec)
+}
+
+func (b *twoProjects) FetchItemsPage(projectID, statusFieldID, cursor string) (*gh.ItemsPage, error) {
+	return b.pick(projectID).FetchItemsPage(projectID, statusFieldID, cursor)
+}
+
+func (b *twoProjects) FetchPartitions(ctx context.Context, projectID, statusFieldID string, filters []string, concurrency int) <-chan gh.PartitionPage {
+	return b.pick(projectID).FetchPartitions(ctx, projectID, statusFieldID, filters, concurrency)
+}
+
+func (b *twoProjects) FetchItemsSince(projectID, statusFieldID string, since time.Time) (*gh.ItemsDelta, error) {
+	return b.pick(projectID).FetchItemsSince(projectID, statusFieldID, since)
+}
+
+func (b *twoProjects) FetchItemIDs(projectID string) (map[string]struct{}, error) {
+	return b.pick(projectID).FetchItemIDs(projectID)
+}
+
+func (b *twoProjects) UpdateItemStatus(projectID, itemID, fieldID, optionID string) error {
+	return b.pick(projectID).UpdateItemStatus(projectID, itemID, fieldID, optionID)
+}
+
+func (b *twoProjects) ListProjects() ([]gh.ProjectSummary, error) {
+	return []gh.ProjectSummary{{ID: "P_7", Number: 7, Title: "Sprint"}, {ID: "P_8", Number: 8, Title: "Roadmap"}}, nil
+}
+
+// within switches between b's own projects.
+func within(b Backend) Switcher {
+	return Switcher{
+		List: b.ListProjects,
+		Open: func(gh.ProjectSummary) (Backend, Options, error) { return b, Options{}, nil },
+	}
+}
+
+func newSessionHarness(t *testing.T, b Backend, sw Switcher) *harness[Session] {
+	t.Helper()
+	first := New(b, gh.ProjectSpec{Number: 7}, "#7", Options{})
+	return drive(t, NewSession(first, sw), func(s Session) Model { return s.boards[s.active] })
+}
+
+func boardLoaded(m Model, n int) bool {
+	return m.bootstrapped && m.loadedItems == n && !m.paginating
+}
+
+func TestE2E_SessionSwitchesProjects(t *testing.T) {
+	t.Parallel()
+	b := newTwoProjects()
+	h := newSessionHarness(t, b, within(b))
+	h.waitFor("Sprint", func(s Session) bool { return boardLoaded(s.boards[0], 3) })
+	h.key("j") // move Sprint's cursor to check it survives the round trip
+	h.key("l")
+
+	h.key("P")
+	h.waitFor("the picker", func(s Session) bool { return s.picker != nil })
+	mustContain(t, h.m.View(), "▶ #7  Sprint")
+	h.key("j")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	h.waitFor("Roadmap", func(s Session) bool { return len(s.boards) == 2 && boardLoaded(s.boards[1], 2) })
+	if h.m.active != 1 {
+		t.Fatalf("active board = %d, want the new Roadmap board", h.m.active)
+	}
+	view := h.m.View()
+	mustContain(t, view, " Sprint ")
+	mustContain(t, view, "[Roadmap]")
+	if got := h.m.boards[1].height; got != 39 {
+		t.Fatalf("board height = %d, want 39 under the tab line", got)
+	}
+
+	// Tabs cycle back to Sprint as it was left.
+	h.key("]")
+	if h.m.active != 0 || h.m.boards[0].focusCol != 1 {
+		t.Fatalf("after ] active = %d, focus = %d; want Sprint focused on Doing", h.m.active, h.m.boards[0].focusCol)
+	}
+	if lines := strings.Split(h.m.View(), "\n"); !strings.Contains(lines[0], "[Sprint]") {
+		t.Fatalf("first line = %q, want Sprint's tab active", lines[0])
+	}
+
+	// Picking an open project switches to it rather than loading it again.
+	h.key("P")
+	h.waitFor("the picker", func(s Session) bool { return s.picker != nil })
+	h.key("j")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(h.m.boards) != 2 || h.m.active != 1 {
+		t.Fatalf("boards = %d, active = %d; want Roadmap reused", len(h.m.boards), h.m.active)
+	}
+
+	// A click on a tab shows that board.
+	h.dispatch(mouse(2, 0, tea.MouseActionPress, tea.MouseButtonLeft))
+	if h.m.active != 0 {
+		t.Fatalf("clicking Sprint's tab left board %d active", h.m.active)
+	}
+}
+
+func TestE2E_SessionPausesLiveModeInTheBackground(t *testing.T) {
+	t.Parallel()
+	b := newTwoProjects()
+	h := newSessionHarness(t, b, within(b))
+	h.waitFor("Sprint", func(s Session) bool { return boardLoaded(s.boards[0], 3) })
+	h.key("w")
+	h.waitFor("the first live sync", func(s Session) bool { return !s.boards[0].syncing })
+
+	h.key("P")
+	h.waitFor("the picker", func(s Session) bool { return s.picker != nil })
+	h.key("j")
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	h.waitFor("Roadmap", func(s Session) bool { return len(s.boards) == 2 && boardLoaded(s.boards[1], 2) })
+
+	syncs := func() int { return strings.Count(strings.Join(b.Calls(), " "), "FetchItemsSince") }
+	before := syncs()
+	// A tick for Sprint in the background is dropped.
+	h.dispatch(boardMsg{board: 0, msg: watchTickMsg{gen: h.m.boards[0].watchGen}})
+	if h.m.boards[0].syncing {
+		t.Fatal("a background board synced on its live-mode tick")
+	}
+
+	// Coming back catches up once and keeps live mode on.
+	h.key("]")
+	h.waitFor("the catch-up sync", func(s Session) bool { return !s.boards[0].syncing })
+	if got := syncs(); got != before+1 {
+		t.Fatalf("FetchItemsSince calls = %d, want one catch-up after %d", got, before)
+	}
+	if !h.m.boards[0].watching || h.m.boards[0].paused {
+		t.Fatalf("watching = %v, paused = %v; want Sprint live again", h.m.boards[0].watching, h.m.boards[0].paused)
+	}
+	if !h.m.boards[1].paused {
+		t.Fatal("Roadmap should pause once it is in the background")
+	}
+}
+
+func TestE2E_SessionOpensAnotherOwnersProject(t *testing.T) {
+	t.Parallel()
+	b := newTwoProjects()
+	p := fakeProject(4)
+	p.ID, p.Number, p.Title = "P_9", 8, "Infra" // the same number as Roadmap
+	acme := ghfake.New(p)
+	infra := gh.ProjectSummary{ID: "P_9", Number: 8, Title: "Infra", OwnerType: gh.ClientTypeOrganization, OwnerLogin: "acme"}
+	var opened []string
+	h := newSessionHarness(t, b, Switcher{
+		List: func() ([]gh.ProjectSummary, error) {
+			owned, err := b.ListProjects()
+			return append([]gh.ProjectSummary{infra}, owned...), err
+		},
+		Open: func(ps gh.ProjectSummary) (Backend, Options, error) {
+			opened = append(opened, ps.OwnerLogin)
+			if ps.OwnerLogin == "acme" {
+				return acme, Options{}, nil
+			}
+			return b, Options{}, nil
+		},
+	})
+	h.waitLoaded(3)
+
+	h.key("P")
+	h.waitFor("the picker", func(s Session) bool { return s.picker != nil })
+	mustContain(t, h.m.View(), "acme #8  Infra")
+	h.key("k") // up from Sprint to Infra
+	h.dispatch(tea.KeyMsg{Type: tea.KeyEnter})
+	h.waitLoaded(4)
+	if len(opened) != 1 || opened[0] != "acme" {
+		t.Fatalf("opened = %v, want one client for acme", opened)
+	}
+	if !slices.Contains(acme.Calls(), "BootstrapBySpec") {
+		t.Fatalf("acme calls = %v, want the board loaded through acme's client", acme.Calls())
+	}
+}
+
+func TestSession_PickerCancelKeepsBoard(t *testing.T) {
+	t.Parallel()
+	b := newTwoProjects()
+	h := newSessionHarness(t, b, within(b))
+	h.waitFor("Sprint", func(s Session) bool { return boardLoaded(s.boards[0], 3) })
+	h.key("P")
+	h.waitFor("the picker", func(s Session) bool { return s.picker != nil })
+	h.key("q")
+	if h.m.picker != nil || len(h.m.boards) != 1 {
+		t.Fatalf("q left the picker open (%v) or opened a board (%d)", h.m.picker != nil, len(h.m.boards))
+	}
+	mustContain(t, h.m.View(), "Sprint  #7")
+}
+
+func TestSession_OfflineHasNoSwitcher(t *testing.T) {
+	t.Parallel()
+	first := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{Offline: true})
+	var s tea.Model = NewSession(first, Switcher{})
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if got := s.(Session).boards[0].status; got != offlineNotice {
+		t.Fatalf("status = %q, want the offline notice", got)
+	}
+	if cmd == nil {
+		t.Fatal("want the notice to be cleared later")
+	}
+}
+
+func TestSession_IsProjectComparesOwnerBeforeLoading(t *testing.T) {
+	t.Parallel()
+	m := New(nil, gh.ProjectSpec{Number: 1}, "#1", Options{Owner: "alice"})
+	if !m.isProject(gh.ProjectSummary{Number: 1, OwnerLogin: "alice"}) {
+		t.Error("alice's #1 should be the board being loaded")
+	}
+	if m.isProject(gh.ProjectSummary{Number: 1, OwnerLogin: "acme"}) {
+		t.Error("acme's #1 is another project with the same number")
+	}
+}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+type SnapshotFormat string
+
+const (
+	SnapshotPlain    SnapshotFormat = "plain"
+	SnapshotANSI     SnapshotFormat = "ansi"
+	SnapshotMarkdown SnapshotFormat = "markdown"
+)
+
+// SnapshotOptions controls RenderSnapshot.
+type SnapshotOptions struct {
+	Width   int
+	Format  SnapshotFormat
+	GroupBy string // SingleSelect field forming the columns; "" means Status
+	Filter  board.Filter
+	Theme   Theme // colours for SnapshotANSI; the zero value is dark
+}
+
+// RenderSnapshot draws a fully loaded project the way the TUI board does,
+// but as a static string: every card is shown (no scrolling window), columns
+// that do not fit the width wrap onto further rows, and nothing is focused.
+// It never starts a Bubble Tea program, so it is safe for cron jobs and CI.
+func RenderSnapshot(p *gh.Project, opts SnapshotOptions) (string, error) {
+	cols, err := board.Group(p, opts.GroupBy)
+	if err != nil {
+		return "", err
+	}
+	total := 0
+	for i := range cols {
+		cols[i].Items = opts.Filter.Apply(cols[i].Items)
+		total += len(cols[i].Items)
+	}
+
+	if opts.Format == SnapshotMarkdown {
+		return renderMarkdownSnapshot(p, cols), nil
+	}
+
+	// A renderer of its own keeps the profile away from the interactive
+	// board and anything else rendering in this process.
+	profile := termenv.Ascii
+	if opts.Format == SnapshotANSI {
+		profile = termenv.ANSI256
+	}
+	r := lipgloss.NewRenderer(io.Discard)
+	r.SetColorProfile(profile)
+	st := opts.Theme.resolved().stylesFor(r)
+
+	width := opts.Width
+	if width < minColW {
+		width = minColW
+	}
+	perRow := max(1, width/minColW)
+
+	var b strings.Builder
+	b.WriteString(st.title.Render(fmt.Sprintf("%s  #%d", p.Title, p.Number)))
+	fmt.Fprintf(&b, "  %s\n", st.muted.Render(fmt.Sprintf("%d items", total)))
+
+	for start := 0; start < len(cols); start += perRow {
+		end := min(start+perRow, len(cols))
+		m := Model{
+			project:      p,
+			columns:      columnsFromBoard(cols[start:end]),
+			focusCol:     -1,
+			width:        width,
+			bootstrapped: true,
+			ascii:        opts.Format != SnapshotANSI,
+			st:           st,
+		}
+		rows := 0
+		for _, c := range m.columns {
+			rows = max(rows, len(c.items))
+		}
+		// Border (2) + header and separator (2) around every card.
+		b.WriteString(m.renderBoard(rows + 4))
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+func columnsFromBoard(cols []board.Column) []column {
+	out := make([]column, 0, len(cols))
+	for _, c := range cols {
+		out = append(out, column{optionID: c.OptionID, name: c.Name, color: c.Color, desc: c.Description, items: c.Items})
+	}
+	return out
+}
+
+// renderMarkdownSnapshot lays the board out as a single Markdown table with
+// one column per board column, for pasting into PR descriptions.
+func renderMarkdownSnapshot(p *gh.Project, cols []board.Column) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%s** #%d\n\n", p.Title, p.Number)
+	if len(cols) == 0 {
+		b.WriteString("_(no columns)_\n")
+		return b.String()
+	}
+
+	rows := 0
+	header := make([]string, 0, len(cols))
+	for _, c := range cols {
+		header = append(header, mdTableCell(fmt.Sprintf("%s (%d)", c.Name, len(c.Items))))
+		rows = max(rows, len(c.Items))
+	}
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(cols)) + "\n")
+
+	for r := 0; r < rows; r++ {
+		cells := make([]string, 0, len(cols))
+		for _, c := range cols {
+			cell := ""
+			if r < len(c.Items) {
+				cell = mdTableCell(titleRender(c.Items[r]))
+			}
+			cells = append(cells, cell)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return b.String()
+}
+
+func mdTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
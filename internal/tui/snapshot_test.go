@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func snapshotProject() *gh.Project {
+	status := gh.SingleSelectField{
+		ID:   "F",
+		Name: "Status",
+		Options: []gh.SingleSelectOption{
+			{ID: "todo", Name: "Todo"},
+			{ID: "doing", Name: "Doing"},
+			{ID: "done", Name: "Done"},
+		},
+	}
+	items := []gh.Item{
+		{ID: "i1", Title: "investigate xyz", Number: 11, ContentType: gh.ContentIssue, StatusOptionID: "todo", Assignees: []string{"alice"}},
+		{ID: "i2", Title: "implement | queue", Number: 12, ContentType: gh.ContentPullRequest, StatusOptionID: "doing"},
+		{ID: "i3", Title: "ship release", Number: 13, ContentType: gh.ContentIssue, StatusOptionID: "done"},
+	}
+	for i := 0; i < 25; i++ {
+		items = append(items, gh.Item{ID: "t" + itoa(i), Title: "task " + itoa(i), StatusOptionID: "todo"})
+	}
+	return &gh.Project{ID: "P", Title: "Sample Project", Number: 2, Status: status, Fields: []gh.SingleSelectField{status}, Items: items}
+}
+
+func TestRenderSnapshot_PlainShowsEveryCard(t *testing.T) {
+	got, err := RenderSnapshot(snapshotProject(), SnapshotOptions{Width: 100, Format: SnapshotPlain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, got, "Sample Project  #2", "28 items", "Todo (26)", "Doing (1)", "Done (1)", "task 24", "+---")
+	if strings.Contains(got, "more") || strings.Contains(got, "\x1b[") {
+		t.Fatalf("plain snapshot should show every card without ANSI escapes:\n%s", got)
+	}
+}
+
+func TestRenderSnapshot_WrapsColumnsToWidth(t *testing.T) {
+	got, err := RenderSnapshot(snapshotProject(), SnapshotOptions{Width: 50, Format: SnapshotPlain})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 50 columns fit two 22-wide columns per row, so "Done" wraps below.
+	lines := strings.Split(got, "\n")
+	todoRow, doneRow := -1, -1
+	for i, l := range lines {
+		if strings.Contains(l, "Todo (26)") {
+			todoRow = i
+		}
+		if strings.Contains(l, "Done (1)") {
+			doneRow = i
+		}
+	}
+	if todoRow < 0 || doneRow <= todoRow+26 {
+		t.Fatalf("expected Done to wrap below the Todo row (todo=%d done=%d):\n%s", todoRow, doneRow, got)
+	}
+}
+
+func TestRenderSnapshot_MarkdownWithFilter(t *testing.T) {
+	filter, err := board.ParseFilter("-task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := RenderSnapshot(snapshotProject(), SnapshotOptions{Format: SnapshotMarkdown, Filter: filter})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, got,
+		"**Sample Project** #2",
+		"| Todo (1) | Doing (1) | Done (1) |",
+		"| --- | --- | --- |",
+		`| investigate xyz #11 | [PR] implement \| queue #12 | ship release #13 |`,
+	)
+}
+
+func TestRenderSnapshot_ANSILeavesGlobalProfileAlone(t *testing.T) {
+	before := lipgloss.ColorProfile()
+	got, err := RenderSnapshot(snapshotProject(), SnapshotOptions{Width: 100, Format: SnapshotANSI})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "\x1b[") {
+		t.Fatalf("ansi snapshot has no escapes:\n%s", got)
+	}
+	if after := lipgloss.ColorProfile(); after != before {
+		t.Fatalf("colour profile changed from %v to %v", before, after)
+	}
+}
+
+func TestRenderSnapshot_ANSIUsesTheme(t *testing.T) {
+	got, err := RenderSnapshot(snapshotProject(), SnapshotOptions{Width: 100, Format: SnapshotANSI, Theme: lightTheme})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustContain(t, got, "38;5;"+lightTheme.Border)
+}
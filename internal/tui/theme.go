@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme holds the board's colours. Each is an ANSI 256 code ("240") or a
+// hex colour ("#586069"); "" leaves the terminal's default.
+type Theme struct {
+	Name     string
+	Border   string // column and detail borders
+	Focus    string // focused column
+	Selected string // selected card
+	Moving   string // card being moved
+	Changed  string // card changed by the last sync
+	Muted    string // hints, counts and the footer
+	Error    string
+	// Options maps GitHub's option colours (GRAY, BLUE, …) to the colours
+	// column headers are tinted with.
+	Options map[string]string
+	// Mono marks a theme without colours; focus and selection are then
+	// shown with a heavy border and reverse video.
+	Mono bool
+}
+
+var (
+	darkTheme = Theme{
+		Name: "dark", Border: "240", Focus: "40", Selected: "207", Moving: "208", Changed: "220", Muted: "244", Error: "196",
+		Options: map[string]string{
+			"GRAY": "245", "BLUE": "33", "GREEN": "40", "YELLOW": "220",
+			"ORANGE": "208", "RED": "196", "PINK": "205", "PURPLE": "135",
+		},
+	}
+	lightTheme = Theme{
+		Name: "light", Border: "248", Focus: "28", Selected: "127", Moving: "166", Changed: "136", Muted: "242", Error: "160",
+		Options: map[string]string{
+			"GRAY": "242", "BLUE": "25", "GREEN": "28", "YELLOW": "136",
+			"ORANGE": "166", "RED": "160", "PINK": "162", "PURPLE": "91",
+		},
+	}
+	highContrastTheme = Theme{
+		Name: "high-contrast", Border: "255", Focus: "46", Selected: "201", Moving: "208", Changed: "226", Muted: "252", Error: "196",
+		Options: map[string]string{
+			"GRAY": "255", "BLUE": "45", "GREEN": "46", "YELLOW": "226",
+			"ORANGE": "214", "RED": "196", "PINK": "213", "PURPLE": "171",
+		},
+	}
+	monoTheme = Theme{Name: "mono", Mono: true}
+)
+
+var builtinThemes = map[string]Theme{
+	darkTheme.Name:         darkTheme,
+	lightTheme.Name:        lightTheme,
+	highContrastTheme.Name: highContrastTheme,
+	monoTheme.Name:         monoTheme,
+}
+
+// ThemeByName returns a built-in theme: dark, light, high-contrast or mono.
+// "" and "auto" pick mono when NO_COLOR is set, otherwise dark or light to
+// match the terminal background. Call it before the program starts: the
+// background is detected by querying the terminal.
+func ThemeByName(name string) (Theme, error) {
+	switch name {
+	case "", "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return monoTheme, nil
+		}
+		if lipgloss.HasDarkBackground() {
+			return darkTheme, nil
+		}
+		return lightTheme, nil
+	}
+	t, ok := builtinThemes[name]
+	if !ok {
+		names := make([]string, 0, len(builtinThemes))
+		for n := range builtinThemes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Theme{}, fmt.Errorf("unknown theme %q; built-in themes: auto, %s", name, strings.Join(names, ", "))
+	}
+	return t, nil
+}
+
+// With returns t with every colour set in over replacing t's, for user
+// themes that extend a built-in one.
+func (t Theme) With(over Theme) Theme {
+	pick := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	if over.Name != "" {
+		t.Name = over.Name
+	}
+	pick(&t.Border, over.Border)
+	pick(&t.Focus, over.Focus)
+	pick(&t.Selected, over.Selected)
+	pick(&t.Moving, over.Moving)
+	pick(&t.Changed, over.Changed)
+	pick(&t.Muted, over.Muted)
+	pick(&t.Error, over.Error)
+	if len(over.Options) > 0 {
+		opts := make(map[string]string, len(t.Options)+len(over.Options))
+		for k, v := range t.Options {
+			opts[k] = v
+		}
+		for k, v := range over.Options {
+			opts[strings.ToUpper(k)] = v
+		}
+		t.Options = opts
+	}
+	return t
+}
+
+func (t Theme) resolved() Theme {
+	if t.Name == "" && !t.Mono {
+		return darkTheme
+	}
+	return t
+}
+
+// styles are the lipgloss styles a theme renders with.
+type styles struct {
+	column        lipgloss.Style
+	focusedColumn lipgloss.Style
+	card          lipgloss.Style
+	selectedCard  lipgloss.Style
+	movingCard    lipgloss.Style
+	changedCard   lipgloss.Style
+	help          lipgloss.Style
+	muted         lipgloss.Style
+	title         lipgloss.Style
+	body          lipgloss.Style
+	err           lipgloss.Style
+	plain         lipgloss.Style
+	options       map[string]lipgloss.Style
+	// cursor prefixes the selected card, for themes whose attributes a
+	// terminal without colour may drop.
+	cursor string
+}
+
+func color(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+func (t Theme) styles() styles {
+	return t.stylesFor(lipgloss.DefaultRenderer())
+}
+
+// stylesFor builds the styles on r, whose colour profile decides how (and
+// whether) colours are written.
+func (t Theme) stylesFor(r *lipgloss.Renderer) styles {
+	s := styles{
+		column: r.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(color(t.Border)).
+			Padding(0, 1),
+		card:  r.NewStyle(),
+		help:  r.NewStyle().Foreground(color(t.Muted)),
+		muted: r.NewStyle().Foreground(color(t.Muted)),
+		title: r.NewStyle().Bold(true),
+		body: r.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(color(t.Border)).
+			Padding(0, 1),
+		err:     r.NewStyle().Foreground(color(t.Error)).Bold(true),
+		plain:   r.NewStyle(),
+		options: make(map[string]lipgloss.Style, len(t.Options)),
+	}
+	s.focusedColumn = s.column.BorderForeground(color(t.Focus)).Foreground(color(t.Focus))
+	s.selectedCard = s.card.Foreground(color(t.Selected)).Bold(true)
+	s.movingCard = s.card.Foreground(color(t.Moving)).Bold(true)
+	s.changedCard = s.card.Foreground(color(t.Changed))
+	// Mono marks by attribute whatever a user theme extending it left
+	// without a colour.
+	if t.Mono && t.Focus == "" {
+		s.focusedColumn = s.column.Border(lipgloss.ThickBorder()).Bold(true)
+	}
+	if t.Mono && t.Selected == "" {
+		s.selectedCard = s.card.Reverse(true)
+		s.cursor = "> "
+	}
+	if t.Mono && t.Moving == "" {
+		s.movingCard = s.card.Bold(true).Underline(true)
+	}
+	if t.Mono && t.Changed == "" {
+		s.changedCard = s.card.Underline(true)
+	}
+	for name, c := range t.Options {
+		s.options[strings.ToUpper(name)] = r.NewStyle().Foreground(color(c)).Bold(true)
+	}
+	return s
+}
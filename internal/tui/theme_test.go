@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+// Not parallel: t.Setenv.
+func TestThemeByName(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	for _, name := range []string{"", "auto"} {
+		got, err := ThemeByName(name)
+		if err != nil || !got.Mono {
+			t.Errorf("ThemeByName(%q) with NO_COLOR = %+v, %v; want mono", name, got, err)
+		}
+	}
+	// An explicit theme wins over NO_COLOR.
+	if got, err := ThemeByName("light"); err != nil || got.Name != "light" {
+		t.Errorf("ThemeByName(light) = %+v, %v", got, err)
+	}
+	if _, err := ThemeByName("solarized"); err == nil || !strings.Contains(err.Error(), "high-contrast") {
+		t.Errorf("unknown theme: err = %v, want the built-in names", err)
+	}
+}
+
+func TestTheme_With(t *testing.T) {
+	t.Parallel()
+	got := lightTheme.With(Theme{Name: "mine", Focus: "#268bd2", Options: map[string]string{"green": "#859900"}})
+	if got.Name != "mine" || got.Focus != "#268bd2" || got.Border != lightTheme.Border {
+		t.Errorf("With = %+v", got)
+	}
+	if got.Options["GREEN"] != "#859900" || got.Options["RED"] != lightTheme.Options["RED"] {
+		t.Errorf("options = %v", got.Options)
+	}
+	if lightTheme.Options["GREEN"] == "#859900" {
+		t.Error("With modified the base theme's options")
+	}
+}
+
+func TestView_MonoThemeMarksSelectedCard(t *testing.T) {
+	t.Parallel()
+	m := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{Theme: monoTheme})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	out, _ = out.(Model).Update(bootstrapMsg{project: fakeProject(3)})
+	view := out.(Model).View()
+	mustContain(t, view, "┏━", "> Task 0", "  Task 1")
+}
+
+func TestTheme_MonoKeepsUserColours(t *testing.T) {
+	t.Parallel()
+	st := monoTheme.With(Theme{Name: "mine", Selected: "201"}).styles()
+	if st.cursor != "" || st.selectedCard.GetForeground() != lipgloss.Color("201") {
+		t.Errorf("selected card = cursor %q, colour %v; want the user's colour and no cursor", st.cursor, st.selectedCard.GetForeground())
+	}
+	if !st.focusedColumn.GetBold() {
+		t.Error("focus without a colour should keep mono's heavy border")
+	}
+}
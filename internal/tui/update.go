@@ -8,6 +8,7 @@ import (
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pkg/browser"
+	"github.com/shuntaka9576/kanban/internal/cache"
 	"github.com/shuntaka9576/kanban/internal/gh"
 )
 
@@ -31,13 +32,49 @@ type itemMovedMsg struct {
 }
 
 type itemYankedMsg struct {
-	itemID   string
-	title    string
-	comments int
-	capped   bool
+	itemID         string
+	title          string
+	comments       int
+	totalComments  int
+	reviewComments int
+	// totalReviewComments is what GitHub reports, beyond any cap.
+	totalReviewComments int
+	capped              bool
+	omitted             int    // comments dropped to fit the yank budget
+	size                string // final size, reported only when a budget is set
+	err                 error
+
+	// moreReviewThreads says threads past the cap went uncounted, so
+	// totalReviewComments is a lower bound.
+	moreReviewThreads bool
+}
+
+type itemsSyncedMsg struct {
+	items     []gh.Item
+	alive     map[string]struct{} // every item id, when a removal sweep ran
+	total     int
+	syncedAt  time.Time
+	rateLimit gh.RateLimit
+	manual    bool // started with R rather than by live mode
+	err       error
+}
+
+type cacheLoadedMsg struct {
+	snapshot *cache.Snapshot
 	err      error
 }
 
+// retryPageMsg re-requests the page at cursor after an automatic backoff.
+type retryPageMsg struct {
+	cursor string
+}
+
+type itemBodyMsg struct {
+	itemID string
+	body   string
+	err    error
+}
+
 type tickMsg struct{}
 
 type clearStatusMsg struct{}
@@ -45,6 +82,10 @@ type clearStatusMsg struct{}
 const (
 	spinnerInterval = 120 * time.Millisecond
 	statusLifetime  = 4 * time.Second
+	// maxPageRetries is how often a failed items page is retried on its own
+	// (on top of the client's retries) before waiting for the user to press r.
+	maxPageRetries = 3
+	pageRetryBase  = 5 * time.Second
 )
 
 func tickCmd() tea.Cmd {
@@ -57,7 +98,10 @@ func clearStatusAfter(d time.Duration) tea.Cmd {
 
 var errNoStatusField = errors.New("project has no Status SingleSelect field; gh-kanban requires it")
 
-func bootstrapCmd(client *gh.Client, spec gh.ProjectSpec) tea.Cmd {
+// writeClipboard is where yank puts its Markdown; tests swap it out.
+var writeClipboard = clipboard.WriteAll
+
+func bootstrapCmd(client Backend, spec gh.ProjectSpec) tea.Cmd {
 	return func() tea.Msg {
 		res, err := client.BootstrapBySpec(spec)
 		if err != nil {
@@ -74,7 +118,7 @@ func bootstrapCmd(client *gh.Client, spec gh.ProjectSpec) tea.Cmd {
 	}
 }
 
-func fetchItemsPageCmd(client *gh.Client, projectID, statusFieldID, cursor string) tea.Cmd {
+func fetchItemsPageCmd(client Backend, projectID, statusFieldID, cursor string) tea.Cmd {
 	return func() tea.Msg {
 		page, err := client.FetchItemsPage(projectID, statusFieldID, cursor)
 		if err != nil {
@@ -88,62 +132,216 @@ func fetchItemsPageCmd(client *gh.Client, projectID, statusFieldID, cursor strin
 	}
 }
 
+// syncCmd fetches what changed since the last sync. known holds the ids on
+// the board; when they plus the newcomers don't add up to the server's total,
+// something was removed and an id-only sweep finds out what.
+func syncCmd(client Backend, projectID, statusFieldID string, since time.Time, known map[string]struct{}, manual bool) tea.Cmd {
+	return func() tea.Msg {
+		started := time.Now()
+		delta, err := client.FetchItemsSince(projectID, statusFieldID, since)
+		if err != nil {
+			return itemsSyncedMsg{manual: manual, err: err}
+		}
+		count := len(known)
+		for _, it := range delta.Items {
+			if _, ok := known[it.ID]; !ok {
+				count++
+			}
+		}
+		var alive map[string]struct{}
+		if count != delta.TotalItems {
+			if alive, err = client.FetchItemIDs(projectID); err != nil {
+				return itemsSyncedMsg{manual: manual, err: err}
+			}
+		}
+		return itemsSyncedMsg{
+			items:     delta.Items,
+			alive:     alive,
+			total:     delta.TotalItems,
+			syncedAt:  started,
+			rateLimit: delta.RateLimit,
+			manual:    manual,
+		}
+	}
+}
+
+func loadCacheCmd(h *cache.Handle) tea.Cmd {
+	return func() tea.Msg {
+		snap, err := h.Load()
+		return cacheLoadedMsg{snapshot: snap, err: err}
+	}
+}
+
+// saveCacheCmd writes p in the background. Items are copied because later
+// pages and refreshes keep appending to the model's slice.
+func saveCacheCmd(h *cache.Handle, p *gh.Project) tea.Cmd {
+	if h == nil || p == nil {
+		return nil
+	}
+	snapshot := *p
+	snapshot.Items = append([]gh.Item(nil), p.Items...)
+	return func() tea.Msg {
+		// A failed write only costs the next startup its head start.
+		_ = h.Save(&snapshot, time.Now())
+		return nil
+	}
+}
+
+const offlineNotice = "offline: network actions are disabled"
+
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(bootstrapCmd(m.client, m.spec), tickCmd())
+	if m.opts.Offline {
+		if m.opts.Cache == nil {
+			return func() tea.Msg { return cacheLoadedMsg{err: cache.ErrNotFound} }
+		}
+		return loadCacheCmd(m.opts.Cache)
+	}
+	cmds := []tea.Cmd{bootstrapCmd(m.client, m.spec), tickCmd()}
+	if m.opts.Cache != nil {
+		cmds = append(cmds, loadCacheCmd(m.opts.Cache))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok {
+		nm, bodyCmd := nm.loadBody()
+		if bodyCmd != nil {
+			return nm, tea.Batch(cmd, bodyCmd)
+		}
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
 		return m, nil
 
 	case tickMsg:
-		if !m.bootstrapped || m.paginating || m.yanking != "" {
+		if !m.bootstrapped || m.paginating || m.yanking != "" || m.syncing || m.refreshingCache() {
 			m.spinnerFrame++
 			return m, tickCmd()
 		}
 		return m, nil
 
+	case cacheLoadedMsg:
+		if msg.err != nil {
+			if m.opts.Offline {
+				m.err = fmt.Errorf("offline: %w for %s; run once online to populate it", msg.err, m.specLabel)
+				m.bootstrapped = true
+			}
+			// Online, a missing or unreadable cache just means a cold start.
+			return m, nil
+		}
+		if m.bootstrapped {
+			return m, nil // the network beat the disk
+		}
+		m.bootstrapped = true
+		m.setProject(msg.snapshot.Project)
+		m.totalItems = m.loadedItems
+		m.cachedAt = msg.snapshot.SavedAt
+		return m, nil
+
 	case bootstrapMsg:
 		if msg.err != nil {
 			m.err = msg.err
 			m.bootstrapped = true // unblock keys; user can quit/refresh
 			m.paginating = false
+			m.staged = nil
 			return m, nil
 		}
 		m.err = nil
 		m.bootstrapped = true
-		m.setProject(msg.project)
+		if m.cachedAt.IsZero() {
+			m.setProject(msg.project)
+		} else {
+			// Keep showing the cached board until the fresh one is complete.
+			m.staged = msg.project
+		}
+		m.seen = make(map[string]struct{}, msg.totalItems)
+		for _, it := range msg.project.Items {
+			m.seen[it.ID] = struct{}{}
+		}
 		m.nextCursor = msg.nextCursor
 		m.totalItems = msg.totalItems
 		if m.nextCursor != "" {
 			m.paginating = true
+			if filters := gh.StatusPartitions(msg.project.Status); m.totalItems >= gh.MinPartitionedItems && len(filters) > 0 {
+				m.failedPartitions, m.partitionRetry = nil, false
+				return m.startPartitions(msg.project, filters)
+			}
 			return m, tea.Batch(
-				fetchItemsPageCmd(m.client, m.project.ID, m.project.Status.ID, m.nextCursor),
+				fetchItemsPageCmd(m.client, msg.project.ID, msg.project.Status.ID, m.nextCursor),
 				tickCmd(),
 			)
 		}
 		m.paginating = false
-		return m, nil
+		return m.finishLoad()
 
 	case itemsPageMsg:
 		if msg.err != nil {
-			m.err = msg.err
-			m.paginating = false
-			return m, nil
+			return m.pageFailed(msg.err)
 		}
-		m.appendItems(msg.items)
+		m.failedPage = false
+		m.pageRetries = 0
+		m.addItems(msg.items)
 		m.nextCursor = msg.nextCursor
 		if msg.totalItems > 0 {
 			m.totalItems = msg.totalItems
 		}
-		if m.nextCursor != "" && m.project != nil {
+		if p := m.loadingProject(); m.nextCursor != "" && p != nil {
 			m.paginating = true
-			return m, fetchItemsPageCmd(m.client, m.project.ID, m.project.Status.ID, m.nextCursor)
+			return m, fetchItemsPageCmd(m.client, p.ID, p.Status.ID, m.nextCursor)
 		}
 		m.paginating = false
-		return m, nil
+		return m.finishLoad()
+
+	case retryPageMsg:
+		if !m.failedPage || msg.cursor != m.nextCursor {
+			return m, nil // resumed by hand or superseded by a reload
+		}
+		return m.resumePaging()
+
+	case partitionPageMsg:
+		return m.handlePartitionPage(msg)
+
+	case partitionsDoneMsg:
+		return m.handlePartitionsDone(msg)
+
+	case itemsSyncedMsg:
+		m.syncing = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.nextWatch(true)
+		}
+		if m.project == nil || m.staged != nil {
+			return m, nil // a full reload started meanwhile and supersedes this
+		}
+		res := mergeItems(m.project.Items, msg.items, msg.alive)
+		synced := *m.project
+		synced.Items = res.items
+		m.replaceProject(&synced)
+		m.totalItems = msg.total
+		m.lastSync = msg.syncedAt
+		m.rateLimit = msg.rateLimit
+		m.changed = res.changed
+		for id := range res.changed {
+			delete(m.bodies, id)
+		}
+		cmds := []tea.Cmd{saveCacheCmd(m.opts.Cache, m.project), m.nextWatch(false)}
+		// Live mode stays quiet unless something actually happened.
+		if msg.manual || len(res.changed) > 0 || res.removed > 0 {
+			m.status = fmt.Sprintf("✔ Synced: %d changed, %d removed.", len(res.changed), res.removed)
+			cmds = append(cmds, clearStatusAfter(statusLifetime))
+		}
+		return m, tea.Batch(cmds...)
+
+	case watchTickMsg:
+		return m.handleWatchTick(msg)
 
 	case itemMovedMsg:
 		m.movingItem = ""
@@ -159,28 +357,184 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, clearStatusAfter(statusLifetime)
 		}
-		more := ""
-		if msg.capped {
-			more = " (truncated to first 100)"
-		}
-		m.status = fmt.Sprintf("✔ Copied %q (%d comments%s) as Markdown.", msg.title, msg.comments, more)
+		m.status = fmt.Sprintf("✔ Copied %q (%s) as Markdown.", msg.title, yankSummary(msg))
 		return m, clearStatusAfter(statusLifetime)
 
+	case itemBodyMsg:
+		if m.bodyLoading == msg.itemID {
+			m.bodyLoading = ""
+		}
+		if msg.err != nil {
+			// Not retried until the card is selected again.
+			m.bodyFailed = msg.itemID
+			m.status = fmt.Sprintf("load body: %v", msg.err)
+			return m, clearStatusAfter(statusLifetime)
+		}
+		if m.bodies == nil {
+			m.bodies = make(map[string]string)
+		}
+		m.bodies[msg.itemID] = msg.body
+		return m, nil
+
 	case clearStatusMsg:
 		m.status = ""
 		return m, nil
 
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	}
 	return m, nil
 }
 
+// loadBody fetches the selected card's body when the board queries leave
+// bodies out (Options.LazyBodies).
+func (m Model) loadBody() (Model, tea.Cmd) {
+	if !m.opts.LazyBodies || m.opts.Offline || m.client == nil {
+		return m, nil
+	}
+	it := m.currentItem()
+	if it == nil || it.Body != "" || m.bodyLoading == it.ID {
+		return m, nil
+	}
+	if m.bodyFailed != "" {
+		if m.bodyFailed == it.ID {
+			return m, nil
+		}
+		m.bodyFailed = "" // moved on: selecting it again retries
+	}
+	if _, ok := m.bodies[it.ID]; ok {
+		return m, nil
+	}
+	m.bodyLoading = it.ID
+	client, itemID := m.client, it.ID
+	return m, func() tea.Msg {
+		body, err := client.FetchItemBody(itemID)
+		return itemBodyMsg{itemID: itemID, body: body, err: err}
+	}
+}
+
+// pageFailed keeps what has loaded so far and the failed cursor, then
+// retries the page after a growing delay; once the retries are used up the
+// board stays usable, flagged incomplete, until r resumes it.
+func (m Model) pageFailed(err error) (tea.Model, tea.Cmd) {
+	m.paginating = false
+	m.failedPage = true
+	if m.pageRetries >= maxPageRetries {
+		m.err = err
+		return m, nil
+	}
+	m.pageRetries++
+	delay := pageRetryBase << (m.pageRetries - 1)
+	cursor := m.nextCursor
+	m.status = fmt.Sprintf("page failed, retrying in %s (%d/%d)…", delay, m.pageRetries, maxPageRetries)
+	return m, tea.Tick(delay, func(time.Time) tea.Msg { return retryPageMsg{cursor: cursor} })
+}
+
+// resumePaging continues loading from the failed cursor.
+func (m Model) resumePaging() (tea.Model, tea.Cmd) {
+	p := m.loadingProject()
+	if p == nil || m.nextCursor == "" {
+		return m, nil
+	}
+	m.failedPage = false
+	m.err = nil
+	m.status = ""
+	m.paginating = true
+	return m, tea.Batch(fetchItemsPageCmd(m.client, p.ID, p.Status.ID, m.nextCursor), tickCmd())
+}
+
+// loadingProject is the project that incoming pages belong to: the staged
+// one while a cached board is on screen, otherwise the displayed one.
+func (m Model) loadingProject() *gh.Project {
+	if m.staged != nil {
+		return m.staged
+	}
+	return m.project
+}
+
+// finishLoad runs once the last page is in: a staged project replaces the
+// cached board in place, and the result is written back to the cache.
+func (m Model) finishLoad() (tea.Model, tea.Cmd) {
+	if m.staged != nil {
+		m.replaceProject(m.staged)
+		m.staged = nil
+	}
+	m.cachedAt = time.Time{}
+	m.lastSync = time.Now()
+	m.changed = nil
+	return m, tea.Batch(saveCacheCmd(m.opts.Cache, m.project), m.nextWatch(false))
+}
+
+// canSync reports whether the complete board is loaded, so R can fetch just
+// the changes instead of paging everything again.
+func (m Model) canSync() bool {
+	return m.project != nil && !m.lastSync.IsZero() && !m.paginating && m.staged == nil && !m.syncing
+}
+
+// sync starts a delta refresh; unlike refresh the board stays on screen.
+// manual is false for live mode's background syncs.
+func (m Model) sync(manual bool) (tea.Model, tea.Cmd) {
+	known := make(map[string]struct{}, len(m.project.Items))
+	for _, it := range m.project.Items {
+		known[it.ID] = struct{}{}
+	}
+	m.syncing = true
+	m.err = nil
+	return m, tea.Batch(
+		syncCmd(m.client, m.project.ID, m.project.Status.ID, m.lastSync, known, manual),
+		tickCmd(),
+	)
+}
+
+// refreshingCache reports whether a cached board is on screen while the
+// fresh load is still running.
+func (m Model) refreshingCache() bool {
+	return !m.cachedAt.IsZero() && !m.opts.Offline && m.err == nil
+}
+
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "q", "ctrl+c":
+	action, bound := m.keys.action(msg.String())
+	if m.confirmMove {
+		return m.answerMove(msg)
+	}
+	if m.finder != nil {
+		return m.finderKey(msg)
+	}
+	if m.showHelp {
+		// Any key closes the overlay; only ctrl+c still quits.
+		m.showHelp = false
+		if msg.String() == quitKey {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+	if !bound {
+		return m, nil
+	}
+
+	switch action {
+	case ActionQuit:
 		return m, tea.Quit
-	case "R":
+	case ActionHelp:
+		m.showHelp = true
+		return m, nil
+	}
+
+	if m.opts.Offline {
+		switch action {
+		case ActionSync, ActionResume, ActionMoveNext, ActionMovePrev, ActionYank, ActionYankExtended, ActionLive:
+			m.status = offlineNotice
+			return m, clearStatusAfter(statusLifetime)
+		}
+	}
+
+	if action == ActionSync {
+		if m.canSync() {
+			return m.sync(true)
+		}
 		return m.refresh()
 	}
 
@@ -190,47 +544,77 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	col := &m.columns[m.focusCol]
 
-	switch msg.String() {
-	case "h", "left":
+	switch action {
+	case ActionColumnLeft:
 		m.focusCol = m.leftCol()
 
-	case "l", "right":
+	case ActionColumnRight:
 		m.focusCol = m.rightCol()
 
-	case "j", "down":
+	case ActionCursorDown:
 		if len(col.items) > 0 {
 			col.cursor = (col.cursor + 1) % len(col.items)
 		}
 
-	case "k", "up":
+	case ActionCursorUp:
 		if len(col.items) > 0 {
 			col.cursor = (col.cursor - 1 + len(col.items)) % len(col.items)
 		}
 
-	case "n":
-		return m.moveItem(m.rightCol())
+	case ActionMoveNext:
+		return m.guardedMove(m.rightCol())
 
-	case "b":
-		return m.moveItem(m.leftCol())
+	case ActionMovePrev:
+		return m.guardedMove(m.leftCol())
 
-	case "O":
+	case ActionOpenProject:
 		if m.project != nil && m.project.URL != "" {
 			_ = browser.OpenURL(m.project.URL)
 		}
 
-	case "o":
+	case ActionOpen:
 		if it := m.currentItem(); it != nil && it.URL != "" {
 			_ = browser.OpenURL(it.URL)
 		}
 
-	case "y":
-		return m.yankItem()
+	case ActionYank:
+		return m.yankItem(false)
+
+	case ActionYankExtended:
+		return m.yankItem(true)
+
+	case ActionResume:
+		if m.failedPage {
+			m.pageRetries = 0
+			return m.resumePaging()
+		}
+
+	case ActionLive:
+		return m.toggleWatch()
+
+	case ActionCollapse:
+		return m.toggleCollapse()
+
+	case ActionHideColumn:
+		return m.hideColumn()
+
+	case ActionShowColumns:
+		return m.showColumns()
+
+	case ActionShiftLeft:
+		return m.shiftColumn(-1)
+
+	case ActionShiftRight:
+		return m.shiftColumn(1)
+
+	case ActionFind:
+		return m.openFinder()
 	}
 	return m, nil
 }
 
 func (m Model) moveItem(targetCol int) (tea.Model, tea.Cmd) {
-	if m.project == nil || m.project.Status.ID == "" {
+	if m.project == nil || m.groupField().ID == "" {
 		return m, nil
 	}
 	if targetCol == m.focusCol {
@@ -246,7 +630,7 @@ func (m Model) moveItem(targetCol int) (tea.Model, tea.Cmd) {
 	}
 
 	projectID := m.project.ID
-	fieldID := m.project.Status.ID
+	fieldID := m.groupField().ID
 	itemID := item.ID
 	optionID := target.optionID
 	client := m.client
@@ -260,32 +644,54 @@ func (m Model) moveItem(targetCol int) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) yankItem() (tea.Model, tea.Cmd) {
+// yankItem copies the selected item as Markdown. extended adds the timeline
+// and, for PRs, reviews, changed files and the diff.
+func (m Model) yankItem(extended bool) (tea.Model, tea.Cmd) {
 	item := m.currentItem()
 	if item == nil {
 		return m, nil
 	}
 	itemID := item.ID
 	client := m.client
+	budget := m.opts.YankBudget
+	opts := gh.ItemContextOptions{
+		MaxComments:  m.opts.YankMaxComments,
+		Extended:     extended,
+		MaxDiffBytes: m.opts.YankMaxDiffBytes,
+	}
 
 	m.yanking = itemID
 	m.status = "Fetching item context..."
 
 	cmd := tea.Batch(
 		func() tea.Msg {
-			ctx, err := client.FetchItemContext(itemID)
+			ctx, err := client.FetchItemContext(itemID, opts)
 			if err != nil {
 				return itemYankedMsg{itemID: itemID, err: err}
 			}
-			md := renderItemMarkdown(ctx)
-			if err := clipboard.WriteAll(md); err != nil {
+			md, omitted, reviewOmitted := renderItemMarkdownBudget(ctx, budget)
+			if err := writeClipboard(md); err != nil {
 				return itemYankedMsg{itemID: itemID, err: fmt.Errorf("clipboard: %w", err)}
 			}
+			reviewComments := -reviewOmitted
+			for _, t := range ctx.ReviewThreads {
+				reviewComments += len(t.Comments)
+			}
+			size := ""
+			if budget.enabled() {
+				size = budget.describe(md)
+			}
 			return itemYankedMsg{
-				itemID:   itemID,
-				title:    ctx.Title,
-				comments: len(ctx.Comments),
-				capped:   ctx.CommentsCapped,
+				itemID:              itemID,
+				title:               ctx.Title,
+				comments:            len(ctx.Comments) - omitted,
+				totalComments:       ctx.TotalComments,
+				reviewComments:      reviewComments,
+				totalReviewComments: ctx.TotalReviewComments,
+				moreReviewThreads:   len(ctx.ReviewThreads) < ctx.TotalReviewThreads,
+				capped:              ctx.CommentsCapped || ctx.ReviewCommentsCapped,
+				omitted:             omitted + reviewOmitted,
+				size:                size,
 			}
 		},
 		tickCmd(),
@@ -293,6 +699,33 @@ func (m Model) yankItem() (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// yankSummary describes how much discussion made it into the clipboard,
+// e.g. "12 comments" or "100 of 340 comments, 8 of 40 review comments, ~7900 tokens".
+func yankSummary(msg itemYankedMsg) string {
+	summary := fmt.Sprintf("%d comments", msg.comments)
+	if msg.totalComments > msg.comments {
+		summary = fmt.Sprintf("%d of %d comments", msg.comments, msg.totalComments)
+	}
+	switch {
+	case msg.moreReviewThreads:
+		summary += fmt.Sprintf(", %d of %d+ review comments", msg.reviewComments, msg.totalReviewComments)
+	case msg.totalReviewComments > msg.reviewComments:
+		summary += fmt.Sprintf(", %d of %d review comments", msg.reviewComments, msg.totalReviewComments)
+	case msg.reviewComments > 0:
+		summary += fmt.Sprintf(", %d review comments", msg.reviewComments)
+	}
+	if msg.capped {
+		summary += ", capped"
+	}
+	if msg.omitted > 0 {
+		summary += fmt.Sprintf(", %d omitted for budget", msg.omitted)
+	}
+	if msg.size != "" {
+		summary += ", " + msg.size
+	}
+	return summary
+}
+
 func (m Model) refresh() (tea.Model, tea.Cmd) {
 	// Wipe board state but keep spec/specLabel so the loading view shows.
 	m.project = nil
@@ -304,5 +737,16 @@ func (m Model) refresh() (tea.Model, tea.Cmd) {
 	m.loadedItems = 0
 	m.totalItems = 0
 	m.err = nil
+	m.cachedAt = time.Time{}
+	m.staged = nil
+	m.lastSync = time.Time{}
+	m.syncing = false
+	m.stopPartitions()
+	m.seen = nil
+	m.failedPage = false
+	m.pageRetries = 0
+	m.bodies = nil
+	m.bodyLoading = ""
+	m.bodyFailed = ""
 	return m, tea.Batch(bootstrapCmd(m.client, m.spec), tickCmd())
 }
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -16,44 +17,11 @@ const (
 	minBoardH  = 8
 )
 
-var (
-	columnStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(0, 1)
-
-	focusedColumnStyle = columnStyle.
-				BorderForeground(lipgloss.Color("40")).
-				Foreground(lipgloss.Color("40"))
-
-	cardStyle = lipgloss.NewStyle()
-
-	selectedCardStyle = cardStyle.
-				Foreground(lipgloss.Color("207")).
-				Bold(true)
-
-	movingCardStyle = cardStyle.
-			Foreground(lipgloss.Color("208")).
-			Bold(true)
-
-	helpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244"))
-
-	mutedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244"))
-
-	titleStyle = lipgloss.NewStyle().
-			Bold(true)
-
-	bodyStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("240")).
-			Padding(0, 1)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
-			Bold(true)
-)
+// asciiBorder replaces the rounded box-drawing border in plain snapshots.
+var asciiBorder = lipgloss.Border{
+	Top: "-", Bottom: "-", Left: "|", Right: "|",
+	TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+}
 
 var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
@@ -66,11 +34,7 @@ func (m Model) View() string {
 	// states show a placeholder so the user sees the frame at t=0 and the
 	// async progress is reported in the bottom-right footer cell.
 
-	boardLines := m.height - titleLines - bodyTotal - helpLines - 3
-	if boardLines < minBoardH {
-		boardLines = minBoardH
-	}
-
+	boardLines := m.boardLines()
 	header := m.renderHeader()
 
 	var board string
@@ -82,19 +46,60 @@ func (m Model) View() string {
 
 	body := m.renderBody(bodyTotal)
 	footer := m.renderFooter()
+	if m.showHelp {
+		return strings.Join([]string{header, m.renderHelp(lipgloss.Height(board) + lipgloss.Height(body)), footer}, "\n")
+	}
+	if m.finder != nil && m.project != nil {
+		return strings.Join([]string{header, m.renderFinder(lipgloss.Height(board) + lipgloss.Height(body)), footer}, "\n")
+	}
 
 	return strings.Join([]string{header, board, body, footer}, "\n")
 }
 
 func (m Model) renderHeader() string {
 	if m.project != nil {
-		return titleStyle.Render(fmt.Sprintf("%s  #%d", m.project.Title, m.project.Number))
+		title := m.st.title.Render(fmt.Sprintf("%s  #%d", m.project.Title, m.project.Number))
+		if m.failedPage && m.pageRetries >= maxPageRetries {
+			loaded := 0
+			if p := m.loadingProject(); p != nil {
+				loaded = len(p.Items)
+			}
+			hint := ""
+			if k := m.keys.primary(ActionResume); k != "" {
+				hint = fmt.Sprintf(" · %s to retry", k)
+			}
+			title += "  " + m.st.err.Render(fmt.Sprintf("⚠ incomplete: %d of %d loaded%s", loaded, m.totalItems, hint))
+		}
+		// The focused column's option description, as set in the project
+		// settings, e.g. "Doing: work in progress, limit 3".
+		if m.focusCol < len(m.columns) {
+			if col := m.columns[m.focusCol]; col.desc != "" {
+				if room := m.width - lipgloss.Width(title) - 2; room > 0 {
+					title += "  " + m.st.muted.Render(truncate(col.name+": "+col.desc, room))
+				}
+			}
+		}
+		return title
 	}
 	label := m.specLabel
 	if label == "" {
 		label = "project"
 	}
-	return titleStyle.Render(label)
+	return m.st.title.Render(label)
+}
+
+// boardLines is the height of the board, borders included; the board starts
+// right below the title line.
+func (m Model) boardLines() int {
+	return max(m.height-titleLines-bodyTotal-helpLines-3, minBoardH)
+}
+
+// boardRows splits the board's height into the rows inside the column
+// borders and, of those, the rows left for cards under the header and
+// separator.
+func boardRows(boardLines int) (contentRows, cardRows int) {
+	contentRows = max(boardLines-2, 4)
+	return contentRows, max(contentRows-2, 1)
 }
 
 func (m Model) renderBoardPlaceholder(boardLines int) string {
@@ -120,27 +125,56 @@ func (m Model) renderBoardPlaceholder(boardLines int) string {
 		msg = "No items in this project."
 	}
 
-	body := lipgloss.Place(width, contentH, lipgloss.Center, lipgloss.Center, mutedStyle.Render(msg))
-	return columnStyle.Width(width).Render(body)
+	body := lipgloss.Place(width, contentH, lipgloss.Center, lipgloss.Center, m.st.muted.Render(msg))
+	return m.st.column.Width(width).Render(body)
 }
 
 func (m Model) statusMessage() string {
+	if m.confirmMove && m.confirmTarget() >= 0 {
+		return m.st.err.Render(m.confirmPrompt())
+	}
+	if hint := m.dragHint(); hint != "" {
+		return m.st.muted.Render(hint)
+	}
 	if m.err != nil {
-		return errorStyle.Render(fmt.Sprintf("⚠ %v", m.err))
+		return m.st.err.Render(fmt.Sprintf("⚠ %v", m.err))
 	}
 	spin := spinnerFrames[m.spinnerFrame%len(spinnerFrames)]
 	switch {
 	case !m.bootstrapped:
-		return mutedStyle.Render(spin + " resolving project from GitHub…")
+		return m.st.muted.Render(spin + " resolving project from GitHub…")
 	case m.yanking != "":
-		return mutedStyle.Render(spin + " yanking…")
+		return m.st.muted.Render(spin + " yanking…")
+	case m.opts.Offline && m.status == "":
+		return m.st.muted.Render(fmt.Sprintf("offline · cached %s · %d items", m.cachedAt.Local().Format("2006-01-02 15:04"), m.loadedItems))
+	case m.syncing:
+		return m.st.muted.Render(spin + " syncing changes…")
+	case m.refreshingCache():
+		if m.staged != nil {
+			return m.st.muted.Render(spin + " cached, refreshing… " + progressLabel(len(m.staged.Items), m.totalItems))
+		}
+		return m.st.muted.Render(spin + " cached, refreshing…")
 	case m.paginating:
-		return mutedStyle.Render(spin + " " + progressLabel(m.loadedItems, m.totalItems))
+		return m.st.muted.Render(spin + " " + progressLabel(m.loadedItems, m.totalItems))
 	case m.status != "":
-		return mutedStyle.Render(m.status)
+		return m.st.muted.Render(m.status)
+	case m.watching:
+		return m.st.muted.Render(fmt.Sprintf("✓ %s · %d items%s", m.liveLabel(), m.loadedItems, m.apiBudget()))
 	default:
-		return mutedStyle.Render(fmt.Sprintf("✓ ready · %d items", m.loadedItems))
+		return m.st.muted.Render(fmt.Sprintf("✓ ready · %d items%s", m.loadedItems, m.apiBudget()))
+	}
+}
+
+// apiBudget reports the GraphQL points left, e.g. " · API 4870/5000".
+func (m Model) apiBudget() string {
+	if m.client == nil {
+		return ""
+	}
+	rl := m.client.RateLimit()
+	if rl.Limit == 0 {
+		return ""
 	}
+	return fmt.Sprintf(" · API %d/%d", rl.Remaining, rl.Limit)
 }
 
 func progressLabel(loaded, total int) string {
@@ -152,105 +186,133 @@ func progressLabel(loaded, total int) string {
 }
 
 func (m Model) renderFooter() string {
-	help := helpText()
 	status := m.statusMessage()
 
 	width := m.width
 	if width <= 0 {
-		return helpStyle.Render(help)
+		return m.st.help.Render(m.keys.footer())
 	}
 
-	helpW := lipgloss.Width(help)
 	statusW := lipgloss.Width(status)
+	// Help gives way to the status line, an entry at a time.
+	help := m.keys.footerFit(width - statusW - 1)
+	helpW := lipgloss.Width(help)
 
 	// If they fit on one line, place help left and status right with a gap.
-	if helpW+statusW+1 <= width {
+	if help != "" {
 		gap := width - helpW - statusW
 		if gap < 1 {
 			gap = 1
 		}
-		return helpStyle.Render(help) + strings.Repeat(" ", gap) + status
+		return m.st.help.Render(help) + strings.Repeat(" ", gap) + status
 	}
 	// Narrow terminal: drop the help text in favour of the status line.
 	return lipgloss.PlaceHorizontal(width, lipgloss.Right, status)
 }
 
-func helpText() string {
-	return "h/l col  j/k cursor  n/b move  o open  O project  y yank-md  R reload  q quit"
+// renderHelp is the ? overlay: every binding of the active keymap, boxed
+// and centred in a width x height area.
+func (m Model) renderHelp(height int) string {
+	rows := m.keys.helpRows()
+	keyW := 0
+	for _, r := range rows {
+		keyW = max(keyW, lipgloss.Width(r[0]))
+	}
+	lines := []string{m.st.title.Render("Keys"), ""}
+	for _, r := range rows {
+		lines = append(lines, r[0]+strings.Repeat(" ", keyW-lipgloss.Width(r[0])+2)+m.st.muted.Render(r[1]))
+	}
+	lines = append(lines, "", m.st.muted.Render("press any key to close"))
+	box := m.st.body.Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, box)
 }
 
 func (m Model) renderBoard(boardLines int) string {
-	visibleCount := max(1, m.width/minColW)
-	if visibleCount > len(m.columns) {
-		visibleCount = len(m.columns)
-	}
-	firstCol := 0
-	if len(m.columns) > visibleCount {
-		half := visibleCount / 2
-		firstCol = m.focusCol - half
-		if firstCol < 0 {
-			firstCol = 0
-		}
-		if firstCol+visibleCount > len(m.columns) {
-			firstCol = len(m.columns) - visibleCount
-		}
-	}
-	colWidth := m.width / visibleCount
-	if colWidth < minColW {
-		colWidth = minColW
-	}
+	firstCol, lastCol, widths := m.columnWindow()
 
-	contentRows := boardLines - 2 // top + bottom border
-	if contentRows < 4 {
-		contentRows = 4
-	}
-	cardRows := contentRows - 2 // header + separator
-	if cardRows < 1 {
-		cardRows = 1
-	}
+	contentRows, cardRows := boardRows(boardLines)
 
-	textW := colWidth - 4 // border + horizontal padding (1 each side)
-	if textW < 6 {
-		textW = 6
-	}
-
-	rendered := make([]string, 0, visibleCount)
-	for offsetCol := 0; offsetCol < visibleCount; offsetCol++ {
-		i := firstCol + offsetCol
+	rendered := make([]string, 0, lastCol-firstCol)
+	for i := firstCol; i < lastCol; i++ {
 		col := m.columns[i]
+		colWidth := widths[i-firstCol]
+		textW := colWidth - 4 // border + horizontal padding (1 each side)
+		if textW < 6 && !m.strip(i) {
+			textW = 6
+		}
 		focused := i == m.focusCol
-		style := columnStyle
-		if focused {
-			style = focusedColumnStyle
+		style := m.st.column
+		tint, tinted := m.st.options[col.color]
+		over := m.overLimit(col)
+		switch {
+		case over:
+			style = style.BorderForeground(m.st.err.GetForeground())
+			if focused {
+				style = m.st.focusedColumn.BorderForeground(m.st.err.GetForeground())
+			}
+		case focused:
+			style = m.st.focusedColumn
+		case tinted:
+			style = style.BorderForeground(tint.GetForeground())
+		}
+		sepRune := "─"
+		if m.ascii {
+			style = style.Border(asciiBorder)
+			sepRune = "-"
+		}
+
+		headerStyle := m.st.plain
+		switch {
+		case over:
+			headerStyle = m.st.err
+		case tinted:
+			headerStyle = tint
+		}
+		sep := strings.Repeat(sepRune, textW)
+
+		if m.strip(i) {
+			strip := collapsedLines(col, over, textW, contentRows, headerStyle, sep)
+			rendered = append(rendered, style.Width(colWidth-2).Render(strings.Join(strip, "\n")))
+			continue
 		}
 
 		startIdx, endIdx := windowItems(focused, col.cursor, len(col.items), cardRows)
 
-		header := truncate(fmt.Sprintf("%s (%d)", col.name, len(col.items)), textW)
-		sep := strings.Repeat("─", textW)
+		header := headerStyle.Render(truncate(m.columnHeader(col), textW))
 		lines := make([]string, 0, contentRows)
 		lines = append(lines, header, sep)
 
 		if startIdx > 0 {
-			lines[1] = mutedStyle.Render(truncate(fmt.Sprintf("↑ %d above", startIdx), textW))
+			lines[1] = m.st.muted.Render(truncate(fmt.Sprintf("↑ %d above", startIdx), textW))
 		}
 
 		for j := startIdx; j < endIdx; j++ {
 			item := col.items[j]
+			selected := focused && j == col.cursor
 			label := cardLabel(item, textW)
-			cs := cardStyle
-			if focused && j == col.cursor {
-				cs = selectedCardStyle
+			if m.st.cursor != "" {
+				mark := strings.Repeat(" ", len(m.st.cursor))
+				if selected {
+					mark = m.st.cursor
+				}
+				label = mark + cardLabel(item, textW-len(mark))
+			}
+			cs := m.st.card
+			if _, ok := m.changed[item.ID]; ok {
+				cs = m.st.changedCard
+			}
+			if selected {
+				cs = m.st.selectedCard
 			}
 			if m.movingItem != "" && item.ID == m.movingItem {
-				cs = movingCardStyle
+				cs = m.st.movingCard
 			}
 			lines = append(lines, cs.Render(label))
 		}
 		if endIdx < len(col.items) {
 			more := len(col.items) - endIdx
 			// Replace last visible card line with "+N more" indicator.
-			lines[len(lines)-1] = mutedStyle.Render(truncate(fmt.Sprintf("+ %d more", more), textW))
+			lines[len(lines)-1] = m.st.muted.Render(truncate(fmt.Sprintf("+ %d more", more), textW))
 		}
 
 		// Pad to exact contentRows so JoinHorizontal aligns and box height is stable.
@@ -267,6 +329,26 @@ func (m Model) renderBoard(boardLines int) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
 }
 
+// collapsedLines draws a collapsed column: its count, then its name
+// spelled downwards so the strip can still be told apart.
+func collapsedLines(col column, over bool, textW, rows int, headerStyle lipgloss.Style, sep string) []string {
+	count := strconv.Itoa(col.count())
+	if over && len(count) < textW {
+		count += "!"
+	}
+	lines := []string{headerStyle.Render(truncate(count, textW)), sep}
+	for _, r := range col.name {
+		if len(lines) == rows {
+			break
+		}
+		lines = append(lines, string(r))
+	}
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
 // windowItems returns [start, end) indices of items to render so the cursor
 // stays visible when the column is focused. Unfocused columns simply show the
 // top `rows` items.
@@ -326,10 +408,23 @@ func (m Model) renderBody(bodyHeight int) string {
 			meta += "[" + strings.Join(item.Labels, "][") + "]"
 		}
 		if meta != "" {
-			parts = append(parts, mutedStyle.Render(truncate(meta, textW)))
+			parts = append(parts, m.st.muted.Render(truncate(meta, textW)))
 		}
-		if item.Body != "" {
-			parts = append(parts, truncate(firstLine(item.Body), textW))
+		body := item.Body
+		if body == "" {
+			body = m.bodies[item.ID]
+		}
+		switch {
+		case body != "":
+			parts = append(parts, truncate(firstLine(body), textW))
+		case m.bodyLoading == item.ID:
+			parts = append(parts, m.st.muted.Render("loading body…"))
+		case m.bodyFailed == item.ID:
+			parts = append(parts, m.st.muted.Render(truncate("body failed to load; reselect the card to retry", textW)))
+		case m.opts.Offline:
+			// The cache keeps what the board queries fetched, and they
+			// leave bodies out.
+			parts = append(parts, m.st.muted.Render(truncate("body not available offline", textW)))
 		}
 	}
 
@@ -340,7 +435,7 @@ func (m Model) renderBody(bodyHeight int) string {
 		parts = parts[:contentH]
 	}
 
-	return bodyStyle.Width(width).Render(strings.Join(parts, "\n"))
+	return m.st.body.Width(width).Render(strings.Join(parts, "\n"))
 }
 
 func titleRender(item gh.Item) string {
@@ -394,5 +489,3 @@ func firstLine(s string) string {
 	}
 	return s
 }
-
-
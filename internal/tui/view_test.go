@@ -1,16 +1,21 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/cache"
 	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
 )
 
 func newSizedModel(t *testing.T, w, h int) Model {
 	t.Helper()
-	m := New(nil, gh.ProjectSpec{Title: "Sample Project"}, `"Sample Project"`)
+	m := New(nil, gh.ProjectSpec{Title: "Sample Project"}, `"Sample Project"`, Options{})
 	out, _ := m.Update(tea.WindowSizeMsg{Width: w, Height: h})
 	return out.(Model)
 }
@@ -207,3 +212,227 @@ func itoa(n int) string {
 	}
 	return string(buf[i:])
 }
+
+func TestView_CachedSnapshotThenFreshLoad(t *testing.T) {
+	m := newSizedModel(t, 160, 40)
+	status := gh.SingleSelectField{
+		ID: "F", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}, {ID: "done", Name: "Done"}},
+	}
+	cached := &gh.Project{ID: "P_1", Title: "Sample Project", Status: status, Items: []gh.Item{
+		{ID: "i1", Title: "old title", StatusOptionID: "todo"},
+		{ID: "i2", Title: "second", StatusOptionID: "todo"},
+	}}
+	out, _ := m.Update(cacheLoadedMsg{snapshot: &cache.Snapshot{Project: cached, SavedAt: time.Now()}})
+	m = out.(Model)
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = out.(Model)
+	got := m.View()
+	mustContain(t, got, "old title", "cached, refreshing…")
+
+	// The first fresh page is staged; the cached board stays on screen.
+	fresh := &gh.Project{ID: "P_1", Title: "Sample Project", Status: status, Items: []gh.Item{
+		{ID: "i0", Title: "brand new", StatusOptionID: "todo"},
+	}}
+	out, _ = m.Update(bootstrapMsg{project: fresh, nextCursor: "CUR", totalItems: 3})
+	m = out.(Model)
+	mustContain(t, m.View(), "old title", "cached, refreshing… loaded 1 / 3 items")
+
+	out, _ = m.Update(itemsPageMsg{items: []gh.Item{
+		{ID: "i1", Title: "new title", StatusOptionID: "todo"},
+		{ID: "i2", Title: "second", StatusOptionID: "todo"},
+	}})
+	m = out.(Model)
+	got = m.View()
+	mustContain(t, got, "new title", "brand new", "ready · 3 items")
+	if strings.Contains(got, "old title") || strings.Contains(got, "cached") {
+		t.Fatalf("cached board should have been replaced:\n%s", got)
+	}
+	// The cursor was on i2 and must still be, even though its index moved.
+	if it := m.currentItem(); it == nil || it.ID != "i2" {
+		t.Fatalf("cursor on %+v, want i2", it)
+	}
+}
+
+func TestView_OfflineWithoutCache(t *testing.T) {
+	m := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{Offline: true})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	out, _ = out.(Model).Update(m.Init()())
+	mustContain(t, out.(Model).View(), "no cached snapshot for #7")
+}
+
+func TestView_DeltaSyncKeepsBoard(t *testing.T) {
+	m := newSizedModel(t, 160, 40)
+	project := &gh.Project{ID: "P_1", Title: "Sample Project", Status: gh.SingleSelectField{
+		ID: "F", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}, {ID: "done", Name: "Done"}},
+	}, Items: []gh.Item{
+		{ID: "i1", Title: "first", StatusOptionID: "todo"},
+		{ID: "i2", Title: "second", StatusOptionID: "todo"},
+		{ID: "i3", Title: "third", StatusOptionID: "todo"},
+	}}
+	out, _ := m.Update(bootstrapMsg{project: project})
+	m = out.(Model)
+	for range 2 {
+		out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+		m = out.(Model)
+	}
+
+	// R on a fully loaded board syncs instead of wiping it.
+	out, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = out.(Model)
+	if !m.syncing || m.project == nil || cmd == nil {
+		t.Fatalf("R should start a delta sync and keep the board (syncing=%v)", m.syncing)
+	}
+	mustContain(t, m.View(), "third", "syncing changes…")
+
+	// i1 moved to Done, i2 was deleted.
+	out, _ = m.Update(itemsSyncedMsg{
+		items:    []gh.Item{{ID: "i1", Title: "first", StatusOptionID: "done"}},
+		alive:    map[string]struct{}{"i1": {}, "i3": {}},
+		total:    2,
+		syncedAt: time.Now(),
+	})
+	m = out.(Model)
+	mustContain(t, m.View(), "Todo (1)", "Done (1)", "Synced: 1 changed, 1 removed")
+	if it := m.currentItem(); it == nil || it.ID != "i3" {
+		t.Fatalf("cursor on %+v, want i3", it)
+	}
+}
+
+func TestView_PageErrorRetriesThenResumes(t *testing.T) {
+	m := newSizedModel(t, 160, 40)
+	out, _ := m.Update(bootstrapMsg{project: &gh.Project{
+		ID: "P_1", Title: "Sample Project",
+		Status: gh.SingleSelectField{ID: "F", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}}},
+		Items:  []gh.Item{{ID: "i1", Title: "first", StatusOptionID: "todo"}, {ID: "i2", Title: "second", StatusOptionID: "todo"}},
+	}, nextCursor: "CUR2", totalItems: 5})
+	m = out.(Model)
+
+	pageErr := errors.New("HTTP 502")
+	for attempt := 1; attempt <= maxPageRetries; attempt++ {
+		out, cmd := m.Update(itemsPageMsg{err: pageErr})
+		m = out.(Model)
+		if cmd == nil || m.err != nil {
+			t.Fatalf("attempt %d: expected an automatic retry, err=%v", attempt, m.err)
+		}
+		mustContain(t, m.View(), fmt.Sprintf("retrying in %s (%d/3)", pageRetryBase<<(attempt-1), attempt))
+		// The scheduled retry resumes from the same cursor.
+		out, _ = m.Update(retryPageMsg{cursor: "CUR2"})
+		m = out.(Model)
+		if !m.paginating || m.nextCursor != "CUR2" {
+			t.Fatalf("attempt %d: retry should resume at CUR2, paginating=%v cursor=%q", attempt, m.paginating, m.nextCursor)
+		}
+	}
+
+	out, cmd := m.Update(itemsPageMsg{err: pageErr})
+	m = out.(Model)
+	if cmd != nil || !errors.Is(m.err, pageErr) {
+		t.Fatalf("retries exhausted: want the error surfaced and no retry, err=%v", m.err)
+	}
+	mustContain(t, m.View(), "incomplete: 2 of 5 loaded · r to retry", "first")
+
+	// The partial board stays interactive.
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = out.(Model)
+	if it := m.currentItem(); it == nil || it.ID != "i2" {
+		t.Fatalf("cursor on %+v, want i2", it)
+	}
+
+	out, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = out.(Model)
+	if cmd == nil || !m.paginating || m.err != nil || m.loadedItems != 2 {
+		t.Fatalf("r should resume paging from the failed cursor keeping the 2 loaded items")
+	}
+	out, _ = m.Update(itemsPageMsg{items: []gh.Item{{ID: "i3", StatusOptionID: "todo"}, {ID: "i4", StatusOptionID: "todo"}, {ID: "i5", StatusOptionID: "todo"}}})
+	m = out.(Model)
+	if m.loadedItems != 5 || m.failedPage || strings.Contains(m.View(), "incomplete") {
+		t.Fatalf("loaded=%d failed=%v, want a complete board", m.loadedItems, m.failedPage)
+	}
+}
+
+func TestView_LazyBodyShownOnceLoaded(t *testing.T) {
+	m := New(nil, gh.ProjectSpec{Number: 2}, "#2", Options{LazyBodies: true})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	out, _ = out.(Model).Update(bootstrapMsg{project: &gh.Project{
+		ID: "P_1", Title: "Sample Project",
+		Status: gh.SingleSelectField{ID: "F", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}}},
+		Items:  []gh.Item{{ID: "i1", Title: "first", StatusOptionID: "todo"}},
+	}})
+	m = out.(Model)
+	if strings.Contains(m.View(), "lazy body text") {
+		t.Fatal("body rendered before it was loaded")
+	}
+	out, _ = m.Update(itemBodyMsg{itemID: "i1", body: "lazy body text\nsecond line"})
+	m = out.(Model)
+	mustContain(t, m.View(), "lazy body text")
+	if _, cmd := m.loadBody(); cmd != nil {
+		t.Fatal("a loaded body must not be fetched again")
+	}
+}
+
+// bodiless is fakeProject(n) as the board queries load it, without bodies.
+func bodiless(n int) *gh.Project {
+	p := fakeProject(n)
+	for i := range p.Items {
+		p.Items[i].Body = ""
+	}
+	return p
+}
+
+func TestView_LazyBodyFailureIsReported(t *testing.T) {
+	t.Parallel()
+	m := New(ghfake.New(fakeProject(6)), gh.ProjectSpec{Number: 7}, "#7", Options{LazyBodies: true})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	out, _ = out.(Model).Update(bootstrapMsg{project: bodiless(6)})
+	out, _ = out.(Model).Update(itemBodyMsg{itemID: "I_0", err: errors.New("boom")})
+	m = out.(Model)
+	mustContain(t, m.status, "load body: boom")
+	mustContain(t, m.View(), "body failed to load")
+	if _, ok := m.bodies["I_0"]; ok {
+		t.Fatal("a failed fetch must not be cached as an empty body")
+	}
+	if _, cmd := m.loadBody(); cmd != nil {
+		t.Fatal("the failed body is fetched again while still selected")
+	}
+
+	// Moving away and back fetches it again.
+	for _, k := range []string{"j", "k"} {
+		out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		m = out.(Model)
+	}
+	if m.bodyLoading != "I_0" {
+		t.Fatalf("bodyLoading = %q, want I_0 fetched again", m.bodyLoading)
+	}
+}
+
+func TestView_OfflineSaysBodiesAreMissing(t *testing.T) {
+	t.Parallel()
+	m := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{Offline: true, LazyBodies: true})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	out, _ = out.(Model).Update(bootstrapMsg{project: bodiless(3)})
+	mustContain(t, out.(Model).View(), "body not available offline")
+}
+
+func TestView_FocusedColumnDescription(t *testing.T) {
+	t.Parallel()
+	p := fakeProject(3)
+	p.Status.Options[0].Description = "not started yet"
+	p.Status.Options[1].Color, p.Status.Options[1].Description = "YELLOW", "in progress, one at a time"
+	m := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	out, _ = out.(Model).Update(bootstrapMsg{project: p})
+	m = out.(Model)
+	if got := m.columns[1].color; got != "YELLOW" {
+		t.Fatalf("column colour = %q, want YELLOW", got)
+	}
+
+	header := strings.SplitN(m.View(), "\n", 2)[0]
+	mustContain(t, header, "Todo: not started yet")
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	header = strings.SplitN(out.(Model).View(), "\n", 2)[0]
+	mustContain(t, header, "Doing: in progress, one at a time")
+
+	out, _ = out.(Model).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if header = strings.SplitN(out.(Model).View(), "\n", 2)[0]; strings.Contains(header, ":") {
+		t.Errorf("Done has no description, header = %q", header)
+	}
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+const (
+	defaultWatchInterval = 30 * time.Second
+	// MinWatchInterval keeps live mode from eating the GraphQL budget.
+	MinWatchInterval = 5 * time.Second
+)
+
+// watchTickMsg fires a background sync. gen guards against ticks scheduled
+// before live mode was toggled or rescheduled.
+type watchTickMsg struct {
+	gen int
+}
+
+func (m Model) watchInterval() time.Duration {
+	if m.opts.WatchInterval > 0 {
+		return m.opts.WatchInterval
+	}
+	return defaultWatchInterval
+}
+
+// watchDelay stretches the polling interval as the rate limit runs low and,
+// when it is nearly exhausted, waits for the reset.
+func watchDelay(interval time.Duration, rl gh.RateLimit, now time.Time) time.Duration {
+	switch {
+	case rl.Limit == 0:
+		return interval
+	case rl.Remaining < 100:
+		if wait := rl.ResetAt.Sub(now); wait > interval {
+			return wait
+		}
+	case rl.Remaining*10 < rl.Limit:
+		return interval * 4
+	case rl.Remaining*4 < rl.Limit:
+		return interval * 2
+	}
+	return interval
+}
+
+// scheduleWatch queues the next background sync, superseding any tick
+// already in flight.
+func (m *Model) scheduleWatch(d time.Duration) tea.Cmd {
+	if !m.watching || m.paused {
+		return nil
+	}
+	m.watchGen++
+	m.watchEvery = d
+	gen := m.watchGen
+	return tea.Tick(d, func(time.Time) tea.Msg { return watchTickMsg{gen: gen} })
+}
+
+// nextWatch schedules the sync after this one, backing off on a low rate
+// limit or after an error.
+func (m *Model) nextWatch(failed bool) tea.Cmd {
+	d := watchDelay(m.watchInterval(), m.rateLimit, time.Now())
+	if failed {
+		d *= 2
+	}
+	return m.scheduleWatch(d)
+}
+
+func (m Model) handleWatchTick(msg watchTickMsg) (tea.Model, tea.Cmd) {
+	if !m.watching || m.paused || msg.gen != m.watchGen {
+		return m, nil
+	}
+	if m.canSync() {
+		return m.sync(false)
+	}
+	// A sync or load is already running; its completion schedules the next tick.
+	return m, nil
+}
+
+func (m Model) toggleWatch() (tea.Model, tea.Cmd) {
+	m.watching = !m.watching
+	if !m.watching {
+		m.watchGen++ // drop the pending tick
+		m.changed = nil
+		m.status = "Live mode off."
+		return m, clearStatusAfter(statusLifetime)
+	}
+	m.status = fmt.Sprintf("Live mode on: syncing every %s.", m.watchInterval())
+	if m.canSync() {
+		next, cmd := m.sync(false)
+		return next, tea.Batch(cmd, clearStatusAfter(statusLifetime))
+	}
+	return m, clearStatusAfter(statusLifetime)
+}
+
+// pauseWatch stops live mode's syncs while the board is in the background of
+// a Session, so open boards don't multiply the traffic against one rate
+// limit. Live mode itself stays on.
+func (m *Model) pauseWatch() {
+	m.paused = true
+	m.watchGen++ // drop the pending tick
+}
+
+// resumeWatch brings a board back to the foreground: a live board catches
+// up with what changed while it was paused, and that sync schedules the
+// next tick.
+func (m Model) resumeWatch() (Model, tea.Cmd) {
+	m.paused = false
+	if !m.watching {
+		return m, nil
+	}
+	if !m.canSync() {
+		// Whatever finished while the board was paused scheduled nothing,
+		// so tick anyway; one still running supersedes this tick when done.
+		return m, m.nextWatch(false)
+	}
+	next, cmd := m.sync(false)
+	return next.(Model), cmd
+}
+
+// liveLabel is the idle footer text in live mode.
+func (m Model) liveLabel() string {
+	every := max(m.watchEvery, m.watchInterval())
+	label := fmt.Sprintf("live · every %s", every)
+	if every > m.watchInterval() && m.rateLimit.Limit > 0 {
+		label += fmt.Sprintf(" (slowed, %d/%d points left)", m.rateLimit.Remaining, m.rateLimit.Limit)
+	}
+	return label
+}
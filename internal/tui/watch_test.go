@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func TestWatchDelay(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	interval := 30 * time.Second
+	cases := []struct {
+		name string
+		rl   gh.RateLimit
+		want time.Duration
+	}{
+		{"unknown budget", gh.RateLimit{}, interval},
+		{"plenty left", gh.RateLimit{Limit: 5000, Remaining: 4000}, interval},
+		{"under a quarter", gh.RateLimit{Limit: 5000, Remaining: 1000}, 2 * interval},
+		{"under a tenth", gh.RateLimit{Limit: 5000, Remaining: 400}, 4 * interval},
+		{"nearly out waits for reset", gh.RateLimit{Limit: 5000, Remaining: 50, ResetAt: now.Add(10 * time.Minute)}, 10 * time.Minute},
+		{"nearly out but reset is close", gh.RateLimit{Limit: 5000, Remaining: 50, ResetAt: now.Add(time.Second)}, interval},
+	}
+	for _, tc := range cases {
+		if got := watchDelay(interval, tc.rl, now); got != tc.want {
+			t.Errorf("%s: watchDelay = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWatch_TickSyncsAndHighlightsChanges(t *testing.T) {
+	m := New(nil, gh.ProjectSpec{Number: 2}, "#2", Options{WatchInterval: time.Minute})
+	out, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	out, cmd := out.(Model).Update(bootstrapMsg{project: &gh.Project{
+		ID: "P_1", Title: "Sample Project",
+		Status: gh.SingleSelectField{ID: "F", Options: []gh.SingleSelectOption{{ID: "todo", Name: "Todo"}}},
+		Items:  []gh.Item{{ID: "i1", Title: "first", StatusOptionID: "todo"}},
+	}})
+	m = out.(Model)
+	if cmd == nil || m.watchGen == 0 {
+		t.Fatal("a completed load in live mode should schedule a sync")
+	}
+	mustContain(t, m.View(), "live · every 1m0s")
+
+	// A tick from before the last reschedule is ignored.
+	out, _ = m.Update(watchTickMsg{gen: m.watchGen - 1})
+	if out.(Model).syncing {
+		t.Fatal("stale tick started a sync")
+	}
+	out, _ = m.Update(watchTickMsg{gen: m.watchGen})
+	m = out.(Model)
+	if !m.syncing {
+		t.Fatal("current tick should start a sync")
+	}
+
+	out, _ = m.Update(itemsSyncedMsg{
+		items:     []gh.Item{{ID: "i1", Title: "first (edited)", StatusOptionID: "todo"}},
+		total:     1,
+		syncedAt:  time.Now(),
+		rateLimit: gh.RateLimit{Limit: 5000, Remaining: 400},
+	})
+	m = out.(Model)
+	if _, ok := m.changed["i1"]; !ok {
+		t.Fatalf("changed = %v, want i1 highlighted", m.changed)
+	}
+	if m.watchEvery != 4*time.Minute {
+		t.Fatalf("watchEvery = %s, want backoff to 4m", m.watchEvery)
+	}
+
+	out, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = out.(Model)
+	if m.watching || m.changed != nil {
+		t.Fatal("w should turn live mode off and clear highlights")
+	}
+}
+
+func TestWatch_ResumeMidSyncStillTicks(t *testing.T) {
+	t.Parallel()
+	m := New(nil, gh.ProjectSpec{Number: 2}, "#2", Options{WatchInterval: time.Minute})
+	m.syncing = true
+	m.pauseWatch()
+	gen := m.watchGen
+	m, cmd := m.resumeWatch()
+	if cmd == nil || m.watchGen == gen {
+		t.Fatal("resuming a live board that can't sync yet should still schedule a tick")
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// wipDescRE finds a WIP limit in an option description: "WIP 3", "WIP: 3"
+// or "WIP limit 3" anywhere, or "(3)" as the whole description, so that
+// "Shipped (2024)" has none.
+var wipDescRE = regexp.MustCompile(`(?i)^\s*\(\s*(\d+)\s*\)\s*$|\bwip(?:\s+limit)?\s*:?\s*(\d+)`)
+
+// parseWIPLimit returns the limit written into an option description, 0
+// when there is none.
+func parseWIPLimit(desc string) int {
+	m := wipDescRE.FindStringSubmatch(desc)
+	if m == nil {
+		return 0
+	}
+	digits := m[1]
+	if digits == "" {
+		digits = m[2]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// count is how many cards belong in the column, including those the
+// filter hides.
+func (c column) count() int {
+	return max(c.total, len(c.items))
+}
+
+// wipLimit is the column's WIP limit: Layout.Limits by name first, then the
+// option description. 0 means unlimited.
+func (m Model) wipLimit(c column) int {
+	for name, n := range m.opts.Layout.Limits {
+		if strings.EqualFold(name, c.name) {
+			return n
+		}
+	}
+	return parseWIPLimit(c.desc)
+}
+
+// overLimit reports whether the column holds more cards than its limit.
+func (m Model) overLimit(c column) bool {
+	limit := m.wipLimit(c)
+	return limit > 0 && c.count() > limit
+}
+
+// columnHeader is "Name (count)", or "Name (count/limit)" with a trailing
+// "!" once the limit is exceeded.
+func (m Model) columnHeader(c column) string {
+	limit := m.wipLimit(c)
+	switch {
+	case limit == 0:
+		return fmt.Sprintf("%s (%d)", c.name, c.count())
+	case c.count() > limit:
+		return fmt.Sprintf("%s (%d/%d!)", c.name, c.count(), limit)
+	default:
+		return fmt.Sprintf("%s (%d/%d)", c.name, c.count(), limit)
+	}
+}
+
+// confirmMoveKey accepts a move that would breach a WIP limit; any other
+// key cancels it.
+const confirmMoveKey = "y"
+
+// guardedMove moves the selected card to targetCol, first asking for
+// confirmation when Layout.ConfirmLimits is set and the move would take
+// the target column over its limit.
+func (m Model) guardedMove(targetCol int) (tea.Model, tea.Cmd) {
+	if m.opts.Layout.ConfirmLimits && targetCol != m.focusCol && m.currentItem() != nil {
+		target := m.columns[targetCol]
+		if limit := m.wipLimit(target); limit > 0 && target.count() >= limit {
+			m.confirmMove = true
+			m.confirmOpt = target.optionID
+			return m, nil
+		}
+	}
+	return m.moveItem(targetCol)
+}
+
+// answerMove handles the key pressed while a move awaits confirmation.
+func (m Model) answerMove(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmMove = false
+	switch msg.String() {
+	case confirmMoveKey, "enter":
+		// A sync may have reordered the columns since the prompt.
+		if i := m.confirmTarget(); i >= 0 {
+			return m.moveItem(i)
+		}
+		return m, nil
+	case quitKey:
+		return m, tea.Quit
+	}
+	m.status = "Move cancelled."
+	return m, clearStatusAfter(statusLifetime)
+}
+
+// confirmTarget is the index of the column a confirmation targets, -1 once
+// it is gone.
+func (m Model) confirmTarget() int {
+	for i, c := range m.columns {
+		if c.optionID == m.confirmOpt {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m Model) confirmPrompt() string {
+	target := m.columns[m.confirmTarget()]
+	return fmt.Sprintf("%s is at its WIP limit (%d/%d). Move anyway? %s/n", target.name, target.count(), m.wipLimit(target), confirmMoveKey)
+}
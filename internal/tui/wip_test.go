@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/shuntaka9576/kanban/internal/board"
+	"github.com/shuntaka9576/kanban/internal/gh"
+	"github.com/shuntaka9576/kanban/internal/gh/ghfake"
+)
+
+func TestParseWIPLimit(t *testing.T) {
+	t.Parallel()
+	cases := map[string]int{
+		"":                       0,
+		"(3)":                    3,
+		" ( 5 ) ":                5,
+		"Shipped (2024)":         0,
+		"WIP 4":                  4,
+		"wip: 2, pair if stuck":  2,
+		"WIP limit 6":            6,
+		"one at a time":          0,
+		"Ship by Q3":             0,
+		"(draft) keep it short":  0,
+		"swipe 3 times to close": 0,
+	}
+	for desc, want := range cases {
+		if got := parseWIPLimit(desc); got != want {
+			t.Errorf("parseWIPLimit(%q) = %d, want %d", desc, got, want)
+		}
+	}
+}
+
+func TestColumnHeader_CountsFilteredCards(t *testing.T) {
+	t.Parallel()
+	p := fakeProject(6)
+	p.Status.Options[1].Description = "(2)"
+	p.Fields = []gh.SingleSelectField{p.Status}
+	filter, err := board.ParseFilter(`-"Task 4"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(nil, gh.ProjectSpec{Number: 7}, "#7", Options{Layout: BoardLayout{Filter: filter, Limits: map[string]int{"todo": 1}}})
+	cols := m.layoutColumns(p)
+
+	want := map[string]string{"Todo": "Todo (2/1!)", "Doing": "Doing (2/2)", "Done": "Done (2)"}
+	for _, c := range cols {
+		if got := m.columnHeader(c); got != want[c.name] {
+			t.Errorf("header = %q, want %q", got, want[c.name])
+		}
+	}
+	if !m.overLimit(cols[0]) || m.overLimit(cols[1]) {
+		t.Errorf("overLimit: Todo %v, Doing %v; want true, false", m.overLimit(cols[0]), m.overLimit(cols[1]))
+	}
+}
+
+func TestE2E_MoveOverWIPLimitAsksFirst(t *testing.T) {
+	t.Parallel()
+	b := ghfake.New(fakeProject(6))
+	h := newHarness(t, b, Options{Layout: BoardLayout{Limits: map[string]int{"Doing": 2}, ConfirmLimits: true}})
+	h.waitLoaded(6)
+	mustContain(t, h.m.View(), "Doing (2/2)")
+
+	h.key("n")
+	if !h.m.confirmMove || h.m.movingItem != "" {
+		t.Fatalf("confirmMove = %v, movingItem = %q; want a prompt and no move", h.m.confirmMove, h.m.movingItem)
+	}
+	mustContain(t, h.m.View(), "Doing is at its WIP limit (2/2). Move anyway? y/n")
+	h.key("x")
+	if h.m.confirmMove || h.m.movingItem != "" {
+		t.Fatal("any other key should cancel the move")
+	}
+
+	h.key("n")
+	// A sync that reorders the columns meanwhile doesn't redirect the move.
+	h.m.columns[1], h.m.columns[2] = h.m.columns[2], h.m.columns[1]
+	h.key("y")
+	if h.m.movingItem != "I_0" {
+		t.Fatalf("movingItem = %q after y, want I_0", h.m.movingItem)
+	}
+	h.waitFor("move", func(m Model) bool { return m.movingItem == "" && columnOf(m, "I_0") == "Doing" })
+	mustContain(t, h.m.View(), "Doing (3/2!)")
+
+	// Moves that stay within limits go straight through.
+	h.key("l")
+	h.key("n")
+	if h.m.confirmMove {
+		t.Fatal("moving into Done, which has no limit, should not ask")
+	}
+}
@@ -2,17 +2,56 @@ package tui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/shuntaka9576/kanban/internal/gh"
 )
 
+type yankSection int
+
+const (
+	sectionTimeline yankSection = iota
+	sectionReviews
+	sectionReviewThreads
+	sectionFiles
+	sectionDiff
+)
+
+// yankSections lists the optional sections in the order they are rendered,
+// which is also the order the budget fitter tries to keep them in. The
+// timeline goes before the comments, the rest after them.
+var yankSections = []yankSection{sectionTimeline, sectionReviews, sectionReviewThreads, sectionFiles, sectionDiff}
+
+// yankPlan selects which optional parts of an item make it into the Markdown.
+// A nil keepComments keeps every comment; a nil sections keeps every section
+// the item has; a nil reviewThreads keeps every review comment.
+type yankPlan struct {
+	body         string
+	keepComments []bool
+	sections     map[yankSection]bool
+
+	reviewThreads []gh.ReviewThread // review threads left after pruning
+	reviewOmitted int               // oldest review comments pruned from them
+}
+
+func (p yankPlan) keepComment(i int) bool {
+	return p.keepComments == nil || p.keepComments[i]
+}
+
+func (p yankPlan) keepSection(s yankSection) bool {
+	return p.sections == nil || p.sections[s]
+}
+
 func renderItemMarkdown(ctx *gh.ItemContext) string {
 	if ctx == nil {
 		return ""
 	}
+	return renderYank(ctx, yankPlan{body: ctx.Body})
+}
 
+func renderYank(ctx *gh.ItemContext, plan yankPlan) string {
 	var b strings.Builder
 
 	header := ctx.Title
@@ -51,10 +90,10 @@ func renderItemMarkdown(ctx *gh.ItemContext) string {
 	}
 
 	b.WriteString("\n## Body\n\n")
-	if strings.TrimSpace(ctx.Body) == "" {
+	if strings.TrimSpace(plan.body) == "" {
 		b.WriteString("_(no body)_\n")
 	} else {
-		b.WriteString(strings.TrimRight(ctx.Body, "\n"))
+		b.WriteString(strings.TrimRight(plan.body, "\n"))
 		b.WriteString("\n")
 	}
 
@@ -62,36 +101,263 @@ func renderItemMarkdown(ctx *gh.ItemContext) string {
 		return b.String()
 	}
 
+	if plan.keepSection(sectionTimeline) {
+		writeSection(&b, ctx, plan, sectionTimeline)
+	}
+
 	b.WriteString("\n## Comments")
 	if ctx.CommentsCapped {
-		b.WriteString(" (truncated to first 100)")
+		fmt.Fprintf(&b, " (first %d of %d)", len(ctx.Comments), ctx.TotalComments)
 	}
 	b.WriteString("\n\n")
 
 	if len(ctx.Comments) == 0 {
 		b.WriteString("_(no comments)_\n")
-		return b.String()
 	}
+	omitted := 0
 	for i, c := range ctx.Comments {
-		login := c.Author
-		if login == "" {
-			login = "ghost"
+		if !plan.keepComment(i) {
+			omitted++
+			continue
+		}
+		writeOmitted(&b, i, omitted)
+		omitted = 0
+		writeComment(&b, i, c)
+	}
+	writeOmitted(&b, len(ctx.Comments), omitted)
+
+	for _, s := range yankSections[1:] {
+		if plan.keepSection(s) {
+			writeSection(&b, ctx, plan, s)
+		}
+	}
+	return b.String()
+}
+
+// writeSection renders one optional section, or nothing when the item has
+// no such section.
+func writeSection(b *strings.Builder, ctx *gh.ItemContext, plan yankPlan, s yankSection) {
+	if ctx.ContentType == gh.ContentDraftIssue {
+		return
+	}
+	pr := ctx.Extended && ctx.ContentType == gh.ContentPullRequest
+	switch {
+	case s == sectionTimeline && ctx.Extended:
+		writeTimeline(b, ctx.Timeline)
+	case s == sectionReviews && pr:
+		writeReviews(b, ctx.Reviews)
+	case s == sectionReviewThreads && len(ctx.ReviewThreads) > 0:
+		threads := plan.reviewThreads
+		if threads == nil {
+			threads = ctx.ReviewThreads
+		}
+		writeReviewThreads(b, ctx, threads, plan.reviewOmitted)
+	case s == sectionFiles && pr:
+		writeChangedFiles(b, ctx.Files)
+	case s == sectionDiff && pr:
+		writeDiff(b, ctx)
+	}
+}
+
+// writeComment renders the comment at index i.
+func writeComment(b *strings.Builder, i int, c gh.Comment) {
+	fmt.Fprintf(b, "### %d. %s\n\n", i+1, commentHeading(c))
+	writeCommentBody(b, c.Body)
+}
+
+// writeOmitted summarises a run of n dropped comments ending just before the
+// comment at index next.
+func writeOmitted(b *strings.Builder, next, n int) {
+	switch {
+	case n == 0:
+	case n == 1:
+		fmt.Fprintf(b, "_… 1 comment omitted (#%d) …_\n\n", next)
+	default:
+		fmt.Fprintf(b, "_… %d comments omitted (#%d–#%d) …_\n\n", n, next-n+1, next)
+	}
+}
+
+func writeTimeline(b *strings.Builder, events []gh.TimelineEvent) {
+	b.WriteString("\n## Timeline\n\n")
+	if len(events) == 0 {
+		b.WriteString("_(no key events)_\n")
+		return
+	}
+	for _, ev := range events {
+		actor := ev.Actor
+		if actor == "" {
+			actor = "ghost"
 		}
 		ts := ""
-		if !c.CreatedAt.IsZero() {
-			ts = " — " + c.CreatedAt.UTC().Format(time.RFC3339)
+		if !ev.CreatedAt.IsZero() {
+			ts = ev.CreatedAt.UTC().Format(time.RFC3339) + " "
+		}
+		fmt.Fprintf(b, "- %s@%s %s\n", ts, actor, timelineAction(ev))
+	}
+}
+
+func timelineAction(ev gh.TimelineEvent) string {
+	switch ev.Kind {
+	case gh.EventLabeled:
+		return fmt.Sprintf("added label `%s`", ev.Subject)
+	case gh.EventUnlabeled:
+		return fmt.Sprintf("removed label `%s`", ev.Subject)
+	case gh.EventClosed:
+		if ev.Subject != "" {
+			return "closed via " + ev.Subject
 		}
-		fmt.Fprintf(&b, "### %d. @%s%s\n\n", i+1, login, ts)
-		body := strings.TrimRight(c.Body, "\n")
-		if body == "" {
-			body = "_(empty)_"
+		return "closed"
+	case gh.EventReopened:
+		return "reopened"
+	case gh.EventMerged:
+		if ev.Subject != "" {
+			return "merged " + ev.Subject
+		}
+		return "merged"
+	case gh.EventCrossReferenced:
+		return "referenced from " + ev.Subject
+	default:
+		return string(ev.Kind)
+	}
+}
+
+func writeReviews(b *strings.Builder, reviews []gh.Review) {
+	b.WriteString("\n## Reviews\n\n")
+	if len(reviews) == 0 {
+		b.WriteString("_(no reviews)_\n")
+		return
+	}
+	for _, r := range reviews {
+		fmt.Fprintf(b, "### %s: %s\n\n", commentHeading(gh.Comment{Author: r.Author, CreatedAt: r.SubmittedAt}), r.State)
+		if strings.TrimSpace(r.Body) != "" {
+			writeCommentBody(b, r.Body)
 		}
-		b.WriteString(body)
-		b.WriteString("\n\n")
 	}
-	return b.String()
+}
+
+func writeChangedFiles(b *strings.Builder, files []gh.ChangedFile) {
+	fmt.Fprintf(b, "\n## Changed files (%d)\n\n", len(files))
+	for _, f := range files {
+		fmt.Fprintf(b, "- `%s` %s +%d -%d\n", f.Path, strings.ToLower(f.ChangeType), f.Additions, f.Deletions)
+	}
+}
+
+func writeDiff(b *strings.Builder, ctx *gh.ItemContext) {
+	b.WriteString("\n## Diff")
+	if ctx.DiffTruncated {
+		b.WriteString(" (truncated)")
+	}
+	b.WriteString("\n\n")
+	if ctx.DiffError != "" {
+		fmt.Fprintf(b, "_(diff unavailable: %s)_\n", ctx.DiffError)
+		return
+	}
+	if ctx.Diff == "" {
+		b.WriteString("_(no diff)_\n")
+		return
+	}
+	fence := codeFence(ctx.Diff)
+	b.WriteString(fence + "diff\n")
+	b.WriteString(strings.TrimRight(ctx.Diff, "\n"))
+	b.WriteString("\n" + fence + "\n")
+}
+
+// writeReviewThreads renders inline review conversations grouped by file,
+// then by line within each file. threads is ctx's threads less the omitted
+// comments the budget pruned.
+func writeReviewThreads(b *strings.Builder, ctx *gh.ItemContext, threads []gh.ReviewThread, omitted int) {
+	b.WriteString("\n## Review threads")
+	if ctx.ReviewCommentsCapped {
+		reviewComments := 0
+		for _, t := range ctx.ReviewThreads {
+			reviewComments += len(t.Comments)
+		}
+		if len(ctx.ReviewThreads) < ctx.TotalReviewThreads {
+			fmt.Fprintf(b, " (first %d comments, in %d of %d threads)", reviewComments, len(ctx.ReviewThreads), ctx.TotalReviewThreads)
+		} else {
+			fmt.Fprintf(b, " (first %d of %d comments)", reviewComments, ctx.TotalReviewComments)
+		}
+	}
+	b.WriteString("\n")
+	switch {
+	case omitted == 1:
+		b.WriteString("\n_… 1 older review comment omitted …_\n")
+	case omitted > 1:
+		fmt.Fprintf(b, "\n_… %d older review comments omitted …_\n", omitted)
+	}
+
+	threads = append([]gh.ReviewThread(nil), threads...)
+	sort.SliceStable(threads, func(i, j int) bool {
+		if threads[i].Path != threads[j].Path {
+			return threads[i].Path < threads[j].Path
+		}
+		return threads[i].Line < threads[j].Line
+	})
+
+	path := ""
+	for i, t := range threads {
+		if i == 0 || t.Path != path {
+			path = t.Path
+			fmt.Fprintf(b, "\n### `%s`\n", path)
+		}
+		anchor := "file"
+		if t.Line > 0 {
+			anchor = fmt.Sprintf("line %d", t.Line)
+		}
+		var flags []string
+		if t.IsResolved {
+			flags = append(flags, "resolved")
+		}
+		if t.IsOutdated {
+			flags = append(flags, "outdated")
+		}
+		if len(flags) > 0 {
+			anchor += " (" + strings.Join(flags, ", ") + ")"
+		}
+		fmt.Fprintf(b, "\n#### %s\n\n", anchor)
+		for _, c := range t.Comments {
+			fmt.Fprintf(b, "**%s**\n\n", commentHeading(c))
+			writeCommentBody(b, c.Body)
+		}
+	}
+}
+
+func commentHeading(c gh.Comment) string {
+	login := c.Author
+	if login == "" {
+		login = "ghost"
+	}
+	ts := ""
+	if !c.CreatedAt.IsZero() {
+		ts = " — " + c.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return "@" + login + ts
+}
+
+func writeCommentBody(b *strings.Builder, body string) {
+	body = strings.TrimRight(body, "\n")
+	if body == "" {
+		body = "_(empty)_"
+	}
+	b.WriteString(body)
+	b.WriteString("\n\n")
 }
 
 func writeMeta(b *strings.Builder, key, value string) {
 	fmt.Fprintf(b, "- **%s**: %s\n", key, value)
 }
+
+// codeFence is a backtick fence longer than any backtick run in s, so a
+// diff of Markdown with its own fences can't close the block early.
+func codeFence(s string) string {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return strings.Repeat("`", max(3, longest+1))
+}
@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+type BudgetUnit int
+
+const (
+	BudgetTokens BudgetUnit = iota
+	BudgetBytes
+)
+
+// bytesPerToken is the usual rule of thumb for English prose and code with
+// BPE tokenizers. Good enough to stay under a context window, not exact.
+const bytesPerToken = 4
+
+// YankBudget caps the size of the yanked Markdown. The zero value means no
+// budget.
+type YankBudget struct {
+	Limit int
+	Unit  BudgetUnit
+}
+
+// yankBudgetRe matches a count with an optional "k" (thousands of the unit
+// that follows, tokens by default) or "kb"/"kib" (KiB) multiplier and an
+// optional unit word, spaces allowed between them.
+var yankBudgetRe = regexp.MustCompile(`^(\d+)\s*(?:(kib|kb)|(k))?\s*(tokens|tok|t|bytes|b)?$`)
+
+// ParseYankBudget parses budgets such as "8000", "8k", "8k tokens",
+// "8000tokens" (tokens) or "32kb", "32768b", "32768 bytes" (bytes). An empty
+// string or "0" disables the budget.
+func ParseYankBudget(s string) (YankBudget, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" {
+		return YankBudget{}, nil
+	}
+	m := yankBudgetRe.FindStringSubmatch(v)
+	if m == nil {
+		return YankBudget{}, fmt.Errorf("invalid yank budget %q: want e.g. 8000, 8k, 8k tokens, 32kb or 32768b", s)
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return YankBudget{}, fmt.Errorf("invalid yank budget %q: %w", s, err)
+	}
+	unit := BudgetTokens
+	if strings.HasPrefix(m[4], "b") {
+		unit = BudgetBytes
+	}
+	switch {
+	case m[2] != "" && m[4] != "" && unit != BudgetBytes:
+		return YankBudget{}, fmt.Errorf("invalid yank budget %q: %s is a byte count", s, m[2])
+	case m[2] != "":
+		return YankBudget{Limit: n * 1024, Unit: BudgetBytes}, nil
+	case m[3] != "":
+		n *= 1000
+	}
+	return YankBudget{Limit: n, Unit: unit}, nil
+}
+
+func (b YankBudget) enabled() bool { return b.Limit > 0 }
+
+func (b YankBudget) measure(s string) int {
+	return b.measureLen(len(s))
+}
+
+// measureLen is measure for a string of n bytes.
+func (b YankBudget) measureLen(n int) int {
+	if b.Unit == BudgetBytes {
+		return n
+	}
+	return (n + bytesPerToken - 1) / bytesPerToken
+}
+
+func (b YankBudget) fits(s string) bool {
+	return b.fitsLen(len(s))
+}
+
+func (b YankBudget) fitsLen(n int) bool {
+	return !b.enabled() || b.measureLen(n) <= b.Limit
+}
+
+// describe reports the size of s in the budget's unit, e.g. "~1840 tokens".
+func (b YankBudget) describe(s string) string {
+	if b.Unit == BudgetBytes {
+		return fmt.Sprintf("%d bytes", len(s))
+	}
+	return fmt.Sprintf("~%d tokens", b.measure(s))
+}
+
+func (b YankBudget) limitBytes() int {
+	if b.Unit == BudgetBytes {
+		return b.Limit
+	}
+	return b.Limit * bytesPerToken
+}
+
+// renderItemMarkdownBudget renders ctx as Markdown that fits within budget,
+// degrading in priority order: the title, metadata and body always stay (the
+// body is cut only if it alone overflows), then comments are added newest
+// and most-reacted first, then the optional sections. Dropped comments are
+// summarised in place. Review threads that do not fit whole lose their oldest
+// comments before the section goes. It returns the Markdown, the number of
+// comments left out and the number of review comments left out.
+//
+// Each comment, omission marker and section is measured once and the plan
+// is fitted by adding up sizes, so only the bare skeleton and the final
+// Markdown are rendered whole.
+func renderItemMarkdownBudget(ctx *gh.ItemContext, budget YankBudget) (string, int, int) {
+	if ctx == nil {
+		return "", 0, 0
+	}
+	full := renderItemMarkdown(ctx)
+	if budget.fits(full) {
+		return full, 0, 0
+	}
+
+	plan := yankPlan{
+		body:         ctx.Body,
+		keepComments: make([]bool, len(ctx.Comments)),
+		sections:     map[yankSection]bool{},
+	}
+	skeleton := renderYank(ctx, plan)
+	if !budget.fits(skeleton) {
+		overhead := len(skeleton) - len(plan.body)
+		plan.body = truncateBody(ctx.Body, budget.limitBytes()-overhead)
+		skeleton = renderYank(ctx, plan)
+	}
+	size := len(skeleton)
+
+	omitted := len(ctx.Comments)
+	if ctx.ContentType != gh.ContentDraftIssue {
+		var kept []int // sorted indices of the comments kept so far
+		for _, i := range commentPriority(ctx.Comments) {
+			// Keeping i splits the run of omitted comments around it, so
+			// its marker is replaced by up to two.
+			at, _ := slices.BinarySearch(kept, i)
+			lo, hi := 0, len(ctx.Comments)
+			if at > 0 {
+				lo = kept[at-1] + 1
+			}
+			if at < len(kept) {
+				hi = kept[at]
+			}
+			grow := commentLen(i, ctx.Comments[i]) +
+				omittedLen(i, i-lo) + omittedLen(hi, hi-i-1) - omittedLen(hi, hi-lo)
+			if !budget.fitsLen(size + grow) {
+				continue
+			}
+			kept = slices.Insert(kept, at, i)
+			plan.keepComments[i] = true
+			size += grow
+			omitted--
+		}
+	}
+
+	reviewOmitted := 0
+	for _, s := range yankSections {
+		if s == sectionReviewThreads {
+			var grow int
+			grow, reviewOmitted = fitReviewThreads(ctx, &plan, budget, size)
+			size += grow
+			continue
+		}
+		var b strings.Builder
+		writeSection(&b, ctx, plan, s)
+		if budget.fitsLen(size + b.Len()) {
+			plan.sections[s] = true
+			size += b.Len()
+		}
+	}
+	return renderYank(ctx, plan), omitted, reviewOmitted
+}
+
+// fitReviewThreads keeps as much of the review threads section as fits on
+// top of size, pruning the oldest review comments before dropping it. It
+// returns the bytes the section adds and the number of comments left out.
+func fitReviewThreads(ctx *gh.ItemContext, plan *yankPlan, budget YankBudget, size int) (int, int) {
+	total := 0
+	for _, t := range ctx.ReviewThreads {
+		total += len(t.Comments)
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	sectionLen := func(drop int) int {
+		p := *plan
+		p.reviewThreads, p.reviewOmitted = pruneReviewThreads(ctx.ReviewThreads, drop), drop
+		var b strings.Builder
+		writeSection(&b, ctx, p, sectionReviewThreads)
+		return b.Len()
+	}
+	// Each pruned comment shrinks the section by far more than its count
+	// grows the omission note, so the fewest to drop can be bisected.
+	drop := sort.Search(total, func(n int) bool {
+		return budget.fitsLen(size + sectionLen(n))
+	})
+	if drop == total {
+		return 0, total
+	}
+	grow := sectionLen(drop)
+	plan.sections[sectionReviewThreads] = true
+	if drop > 0 {
+		plan.reviewThreads, plan.reviewOmitted = pruneReviewThreads(ctx.ReviewThreads, drop), drop
+	}
+	return grow, drop
+}
+
+// pruneReviewThreads drops the n oldest comments across threads, and the
+// threads that are left without any.
+func pruneReviewThreads(threads []gh.ReviewThread, n int) []gh.ReviewThread {
+	type ref struct{ thread, comment int }
+	var refs []ref
+	for i, t := range threads {
+		for j := range t.Comments {
+			refs = append(refs, ref{i, j})
+		}
+	}
+	sort.SliceStable(refs, func(a, b int) bool {
+		return threads[refs[a].thread].Comments[refs[a].comment].CreatedAt.
+			Before(threads[refs[b].thread].Comments[refs[b].comment].CreatedAt)
+	})
+	dropped := make(map[ref]bool, n)
+	for _, r := range refs[:n] {
+		dropped[r] = true
+	}
+
+	kept := make([]gh.ReviewThread, 0, len(threads))
+	for i, t := range threads {
+		var comments []gh.Comment
+		for j, c := range t.Comments {
+			if !dropped[ref{i, j}] {
+				comments = append(comments, c)
+			}
+		}
+		if len(comments) > 0 {
+			t.Comments = comments
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+func commentLen(i int, c gh.Comment) int {
+	var b strings.Builder
+	writeComment(&b, i, c)
+	return b.Len()
+}
+
+func omittedLen(next, n int) int {
+	var b strings.Builder
+	writeOmitted(&b, next, n)
+	return b.Len()
+}
+
+// commentPriority orders comment indices by how much they are worth keeping:
+// alternately the newest remaining one and the most-reacted remaining one.
+func commentPriority(comments []gh.Comment) []int {
+	byReactions := make([]int, len(comments))
+	for i := range byReactions {
+		byReactions[i] = len(comments) - 1 - i // newest first breaks ties
+	}
+	sort.SliceStable(byReactions, func(a, b int) bool {
+		return comments[byReactions[a]].Reactions > comments[byReactions[b]].Reactions
+	})
+
+	order := make([]int, 0, len(comments))
+	seen := make([]bool, len(comments))
+	newest, reacted := len(comments)-1, 0
+	for len(order) < len(comments) {
+		for newest >= 0 && seen[newest] {
+			newest--
+		}
+		if newest >= 0 {
+			seen[newest] = true
+			order = append(order, newest)
+		}
+		for reacted < len(byReactions) && seen[byReactions[reacted]] {
+			reacted++
+		}
+		if reacted < len(byReactions) {
+			seen[byReactions[reacted]] = true
+			order = append(order, byReactions[reacted])
+		}
+	}
+	return order
+}
+
+const bodyTruncatedMarker = "\n\n_… body truncated to fit the yank budget …_"
+
+// truncateBody cuts body to at most maxBytes (marker included) on a rune
+// boundary, preferring to end at a line break.
+func truncateBody(body string, maxBytes int) string {
+	limit := maxBytes - len(bodyTruncatedMarker)
+	if limit <= 0 {
+		return strings.TrimPrefix(bodyTruncatedMarker, "\n\n")
+	}
+	if len(body) <= limit {
+		return body
+	}
+	cut := body[:limit]
+	for !utf8.ValidString(cut) {
+		cut = cut[:len(cut)-1]
+	}
+	if i := strings.LastIndexByte(cut, '\n'); i > limit/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, "\n") + bodyTruncatedMarker
+}
@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shuntaka9576/kanban/internal/gh"
+)
+
+func TestParseYankBudget(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		in      string
+		want    YankBudget
+		wantErr bool
+	}{
+		{"", YankBudget{}, false},
+		{"0", YankBudget{Limit: 0, Unit: BudgetTokens}, false},
+		{"8000", YankBudget{Limit: 8000, Unit: BudgetTokens}, false},
+		{"8k", YankBudget{Limit: 8000, Unit: BudgetTokens}, false},
+		{"8k tokens", YankBudget{Limit: 8000, Unit: BudgetTokens}, false},
+		{"8 k tok", YankBudget{Limit: 8000, Unit: BudgetTokens}, false},
+		{"8000tokens", YankBudget{Limit: 8000, Unit: BudgetTokens}, false},
+		{"2k bytes", YankBudget{Limit: 2000, Unit: BudgetBytes}, false},
+		{"8kb", YankBudget{Limit: 8 * 1024, Unit: BudgetBytes}, false},
+		{"32KB", YankBudget{Limit: 32 * 1024, Unit: BudgetBytes}, false},
+		{"32768b", YankBudget{Limit: 32768, Unit: BudgetBytes}, false},
+		{"100 bytes", YankBudget{Limit: 100, Unit: BudgetBytes}, false},
+		{"lots", YankBudget{}, true},
+		{"-5", YankBudget{}, true},
+		{"8kb tokens", YankBudget{}, true},
+		{"8 tokens k", YankBudget{}, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseYankBudget(tc.in)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseYankBudget(%q) err = %v, wantErr %v", tc.in, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseYankBudget(%q) = %+v, want %+v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRenderItemMarkdownBudget_FitsUnchanged(t *testing.T) {
+	t.Parallel()
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentIssue,
+		Title:       "Small",
+		Comments:    []gh.Comment{{Author: "bob", Body: "hi"}},
+	}
+	got, omitted, _ := renderItemMarkdownBudget(ctx, YankBudget{Limit: 10_000, Unit: BudgetBytes})
+	if omitted != 0 || got != renderItemMarkdown(ctx) {
+		t.Fatalf("expected unchanged rendering within budget, omitted=%d:\n%s", omitted, got)
+	}
+}
+
+func TestRenderItemMarkdownBudget_KeepsNewestAndMostReacted(t *testing.T) {
+	t.Parallel()
+	comments := make([]gh.Comment, 0, 10)
+	for i := 0; i < 10; i++ {
+		comments = append(comments, gh.Comment{
+			Author: "user" + itoa(i),
+			Body:   "comment-" + itoa(i) + " " + strings.Repeat("x", 200),
+		})
+	}
+	comments[2].Reactions = 42 // old but popular
+
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentIssue,
+		Title:       "Long thread",
+		Body:        "the body",
+		Comments:    comments,
+	}
+	budget := YankBudget{Limit: 900, Unit: BudgetBytes}
+	got, omitted, _ := renderItemMarkdownBudget(ctx, budget)
+
+	if !budget.fits(got) {
+		t.Fatalf("rendered %d bytes, over budget of %d:\n%s", len(got), budget.Limit, got)
+	}
+	mustContain(t, got, "the body", "comment-9 ", "comment-2 ", "comments omitted")
+	if strings.Contains(got, "comment-0 ") {
+		t.Fatalf("oldest unreacted comment should have been dropped:\n%s", got)
+	}
+	if omitted == 0 || omitted == len(comments) {
+		t.Fatalf("omitted = %d, want some but not all comments dropped", omitted)
+	}
+}
+
+func TestRenderItemMarkdownBudget_FitsEveryBudget(t *testing.T) {
+	t.Parallel()
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentPullRequest,
+		Title:       "Refactor queue",
+		Body:        "the body",
+		Extended:    true,
+		Files:       []gh.ChangedFile{{Path: "queue.go", Additions: 3, ChangeType: "MODIFIED"}},
+		Diff:        strings.Repeat("+line\n", 40),
+	}
+	for i := range 25 {
+		ctx.Comments = append(ctx.Comments, gh.Comment{
+			Author:    "user" + itoa(i),
+			Body:      strings.Repeat("y", 10+(i*37)%150),
+			Reactions: i % 4,
+		})
+	}
+	// The plan is fitted by adding up measured sizes; the rendered result
+	// has to agree with them at every budget, marker splits included.
+	full := len(renderItemMarkdown(ctx))
+	for limit := 200; limit < full; limit += 53 {
+		budget := YankBudget{Limit: limit, Unit: BudgetBytes}
+		got, omitted, _ := renderItemMarkdownBudget(ctx, budget)
+		if !budget.fits(got) {
+			t.Fatalf("budget %d: rendered %d bytes", limit, len(got))
+		}
+		if kept := strings.Count(got, "\n### "); kept != len(ctx.Comments)-omitted {
+			t.Fatalf("budget %d: %d comments rendered, want %d", limit, kept, len(ctx.Comments)-omitted)
+		}
+	}
+}
+
+func TestRenderItemMarkdownBudget_TruncatesHugeBody(t *testing.T) {
+	t.Parallel()
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentIssue,
+		Title:       "Huge",
+		Body:        strings.Repeat("line of text\n", 1000),
+		Comments:    []gh.Comment{{Author: "bob", Body: strings.Repeat("dropped ", 50)}},
+	}
+	budget := YankBudget{Limit: 200, Unit: BudgetTokens}
+	got, omitted, _ := renderItemMarkdownBudget(ctx, budget)
+	if !budget.fits(got) {
+		t.Fatalf("rendered %s, over budget of %d tokens", budget.describe(got), budget.Limit)
+	}
+	mustContain(t, got, "# Huge", "body truncated", "_… 1 comment omitted (#1) …_")
+	if omitted != 1 {
+		t.Fatalf("omitted = %d, want 1", omitted)
+	}
+}
+
+func TestRenderItemMarkdownBudget_PrunesOldestReviewComments(t *testing.T) {
+	t.Parallel()
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC) }
+	comment := func(d int) gh.Comment {
+		return gh.Comment{Author: "rev", Body: "review-" + itoa(d) + " " + strings.Repeat("z", 150), CreatedAt: day(d)}
+	}
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentPullRequest,
+		Title:       "Tidy parser",
+		Body:        "the body",
+		ReviewThreads: []gh.ReviewThread{
+			{Path: "a.go", Line: 3, Comments: []gh.Comment{comment(1), comment(4)}},
+			{Path: "b.go", Line: 9, Comments: []gh.Comment{comment(2)}},
+			{Path: "c.go", Line: 1, Comments: []gh.Comment{comment(3), comment(5)}},
+		},
+	}
+	budget := YankBudget{Limit: len(renderItemMarkdown(ctx)) - 300, Unit: BudgetBytes}
+	got, _, reviewOmitted := renderItemMarkdownBudget(ctx, budget)
+	if !budget.fits(got) {
+		t.Fatalf("rendered %d bytes, over budget of %d:\n%s", len(got), budget.Limit, got)
+	}
+	mustContain(t, got, "## Review threads", "_… 2 older review comments omitted …_", "review-4 ", "review-5 ", "`c.go`")
+	for _, gone := range []string{"review-1 ", "review-2 ", "`b.go`"} {
+		if strings.Contains(got, gone) {
+			t.Errorf("%q should have been pruned:\n%s", gone, got)
+		}
+	}
+	if reviewOmitted != 2 {
+		t.Fatalf("reviewOmitted = %d, want 2", reviewOmitted)
+	}
+}
@@ -72,16 +72,141 @@ func TestRenderItemMarkdown_PRWithCappedComments(t *testing.T) {
 		ContentType:    gh.ContentPullRequest,
 		Title:          "Refactor queue",
 		Number:         99,
+		TotalComments:  250,
 		CommentsCapped: true,
 	}
 	got := renderItemMarkdown(ctx)
 	mustContain(t, got,
 		"# [PR] #99 Refactor queue",
-		"## Comments (truncated to first 100)",
+		"## Comments (first 0 of 250)",
 		"_(no comments)_",
 	)
 }
 
+func TestRenderItemMarkdown_PRReviewThreads(t *testing.T) {
+	t.Parallel()
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentPullRequest,
+		Title:       "Refactor queue",
+		Number:      99,
+		ReviewThreads: []gh.ReviewThread{
+			{
+				Path:       "internal/queue/queue.go",
+				Line:       42,
+				IsResolved: true,
+				Comments: []gh.Comment{
+					{Author: "bob", Body: "off-by-one here?"},
+					{Author: "alice", Body: "fixed"},
+				},
+			},
+			{Path: "README.md", Comments: []gh.Comment{{Author: "carol", Body: "typo"}}},
+		},
+		TotalReviewComments:  5,
+		ReviewCommentsCapped: true,
+	}
+	got := renderItemMarkdown(ctx)
+	mustContain(t, got,
+		"## Review threads (first 3 of 5 comments)",
+		"### `README.md`\n\n#### file",
+		"### `internal/queue/queue.go`\n\n#### line 42 (resolved)",
+		"**@bob**",
+		"off-by-one here?",
+		"typo",
+	)
+	if strings.Index(got, "README.md") > strings.Index(got, "internal/queue") {
+		t.Fatalf("review threads should be ordered by file path, got:\n%s", got)
+	}
+	for _, section := range []string{"## Timeline", "## Reviews", "## Changed files", "## Diff"} {
+		if strings.Contains(got, section) {
+			t.Fatalf("non-extended yank should not include %q, got:\n%s", section, got)
+		}
+	}
+}
+
+func TestRenderItemMarkdown_ExtendedPR(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2026, 4, 5, 8, 0, 0, 0, time.UTC)
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentPullRequest,
+		Title:       "Refactor queue",
+		Number:      99,
+		Extended:    true,
+		Timeline: []gh.TimelineEvent{
+			{Kind: gh.EventLabeled, Actor: "alice", CreatedAt: at, Subject: "needs-review"},
+			{Kind: gh.EventCrossReferenced, Actor: "bob", CreatedAt: at, Subject: "acme/app#12 Flaky queue"},
+		},
+		Reviews: []gh.Review{
+			{Author: "carol", State: "CHANGES_REQUESTED", Body: "Please add tests.", SubmittedAt: at},
+		},
+		Files: []gh.ChangedFile{
+			{Path: "queue.go", Additions: 10, Deletions: 2, ChangeType: "MODIFIED"},
+		},
+		Diff:          "diff --git a/queue.go b/queue.go\n+added\n",
+		DiffTruncated: true,
+	}
+	got := renderItemMarkdown(ctx)
+	mustContain(t, got,
+		"## Timeline",
+		"- 2026-04-05T08:00:00Z @alice added label `needs-review`",
+		"@bob referenced from acme/app#12 Flaky queue",
+		"## Reviews",
+		"### @carol — 2026-04-05T08:00:00Z: CHANGES_REQUESTED",
+		"Please add tests.",
+		"## Changed files (1)",
+		"- `queue.go` modified +10 -2",
+		"## Diff (truncated)",
+		"```diff\ndiff --git a/queue.go b/queue.go\n+added\n```",
+	)
+}
+
+func TestRenderItemMarkdown_DiffUnavailable(t *testing.T) {
+	t.Parallel()
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentPullRequest,
+		Title:       "Vendor everything",
+		Extended:    true,
+		Files:       []gh.ChangedFile{{Path: "vendor/a.go", Additions: 1, ChangeType: "ADDED"}},
+		DiffError:   "fetch diff: HTTP 406: diff too large",
+	}
+	mustContain(t, renderItemMarkdown(ctx),
+		"## Changed files (1)",
+		"## Diff\n\n_(diff unavailable: fetch diff: HTTP 406: diff too large)_",
+	)
+}
+
+func TestRenderItemMarkdown_DiffFenceOutlastsBackticks(t *testing.T) {
+	t.Parallel()
+	ctx := &gh.ItemContext{
+		ContentType: gh.ContentPullRequest,
+		Title:       "Docs",
+		Extended:    true,
+		Diff:        "diff --git a/README.md b/README.md\n+```sh\n+make\n+````\n",
+	}
+	mustContain(t, renderItemMarkdown(ctx), "`````diff\n", "+````\n`````\n")
+	if got := codeFence("no ticks"); got != "```" {
+		t.Errorf("codeFence = %q, want the usual three backticks", got)
+	}
+}
+
+func TestYankSummary(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		msg  itemYankedMsg
+		want string
+	}{
+		{itemYankedMsg{comments: 12, totalComments: 12}, "12 comments"},
+		{itemYankedMsg{comments: 100, totalComments: 340, capped: true}, "100 of 340 comments, capped"},
+		{itemYankedMsg{comments: 3, totalComments: 3, reviewComments: 8, totalReviewComments: 8}, "3 comments, 8 review comments"},
+		{itemYankedMsg{comments: 3, totalComments: 3, reviewComments: 8, totalReviewComments: 40, capped: true}, "3 comments, 8 of 40 review comments, capped"},
+		{itemYankedMsg{comments: 3, totalComments: 3, reviewComments: 8, totalReviewComments: 12, moreReviewThreads: true, capped: true}, "3 comments, 8 of 12+ review comments, capped"},
+	}
+	for _, tc := range cases {
+		if got := yankSummary(tc.msg); got != tc.want {
+			t.Errorf("yankSummary(%+v) = %q, want %q", tc.msg, got, tc.want)
+		}
+	}
+}
+
 func mustContain(t *testing.T, haystack string, needles ...string) {
 	t.Helper()
 	for _, n := range needles {